@@ -6,7 +6,9 @@ package glib
 import "C"
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"unsafe"
 
 	gopointer "github.com/go-gst/go-pointer"
@@ -76,6 +78,155 @@ func (v *Object) ConnectAfter(detailedSignal string, f interface{}, userData ...
 	return v.connectClosure(true, detailedSignal, f, userData...)
 }
 
+// ConnectTyped connects f to detailedSignal of obj like Object.Connect, but validates the signature of f
+// against the signal when connecting instead of when the signal is emitted. f must be a function taking
+// at most the instance followed by the parameters of the signal, and returning the signal's return
+// value if it has one. An error is returned if the signal does not exist or f does not match it.
+func ConnectTyped[F any](obj IObject, detailedSignal string, f F) (SignalHandle, error) {
+	return connectTyped(obj.toObject(), false, detailedSignal, f)
+}
+
+// ConnectTypedAfter is like ConnectTyped, but f is invoked after the default handler.
+func ConnectTypedAfter[F any](obj IObject, detailedSignal string, f F) (SignalHandle, error) {
+	return connectTyped(obj.toObject(), true, detailedSignal, f)
+}
+
+func connectTyped(v *Object, after bool, detailedSignal string, f interface{}) (SignalHandle, error) {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return 0, errors.New("value is not a func")
+	}
+	if err := v.validateSignalHandler(detailedSignal, ft); err != nil {
+		return 0, err
+	}
+	return v.connectClosure(after, detailedSignal, f)
+}
+
+// validateSignalHandler checks the signature of ft against the signal queried with g_signal_query.
+func (v *Object) validateSignalHandler(detailedSignal string, ft reflect.Type) error {
+	cstr := C.CString(detailedSignal)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var id C.guint
+	var detail C.GQuark
+	if !gobool(C.g_signal_parse_name((*C.gchar)(cstr), C.GType(v.TypeFromInstance()), &id, &detail, C.FALSE)) {
+		return fmt.Errorf("%w: %s on %s", ErrSignalNotFound, detailedSignal, v.TypeFromInstance().Name())
+	}
+
+	var q C.GSignalQuery
+	C.g_signal_query(id, &q)
+
+	// the handler is invoked with the instance followed by the signal parameters
+	paramTypes := []Type{Type(q.itype)}
+	for _, t := range unsafe.Slice(q.param_types, q.n_params) {
+		paramTypes = append(paramTypes, Type(t&^C.G_SIGNAL_TYPE_STATIC_SCOPE))
+	}
+
+	if ft.IsVariadic() || ft.NumIn() > len(paramTypes) {
+		return fmt.Errorf("%w for handler of %s: takes %d, max allowed %d", ErrSignalWrongNumberOfArgs, detailedSignal, ft.NumIn(), len(paramTypes))
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		if err := checkHandlerType(paramTypes[i], ft.In(i), false); err != nil {
+			return fmt.Errorf("handler of %s: argument %d: %w", detailedSignal, i, err)
+		}
+	}
+
+	returnType := Type(q.return_type &^ C.G_SIGNAL_TYPE_STATIC_SCOPE)
+	if returnType == TYPE_NONE || returnType == TYPE_INVALID {
+		if ft.NumOut() != 0 {
+			return fmt.Errorf("handler of %s must not return a value", detailedSignal)
+		}
+		return nil
+	}
+	if ft.NumOut() != 1 {
+		return fmt.Errorf("handler of %s must return a single %s", detailedSignal, returnType.Name())
+	}
+	if err := checkHandlerType(returnType, ft.Out(0), true); err != nil {
+		return fmt.Errorf("handler of %s: return value: %w", detailedSignal, err)
+	}
+	return nil
+}
+
+// checkHandlerType checks that values of gtype can be passed as t to a handler, or that t can be
+// returned as gtype from it if ret is true. Only basic types are checked strictly, other values
+// may be passed as pointers, structs or interfaces depending on their marshalers.
+func checkHandlerType(gtype Type, t reflect.Type, ret bool) error {
+	switch Type(C.g_type_fundamental(C.GType(gtype))) {
+	case TYPE_CHAR, TYPE_UCHAR, TYPE_BOOLEAN, TYPE_INT, TYPE_UINT, TYPE_LONG, TYPE_ULONG,
+		TYPE_INT64, TYPE_UINT64, TYPE_ENUM, TYPE_FLAGS, TYPE_FLOAT, TYPE_DOUBLE, TYPE_STRING:
+	default:
+		switch t.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Struct, reflect.Slice, reflect.Map, reflect.UnsafePointer, reflect.Uintptr:
+			return nil
+		}
+		return fmt.Errorf("cannot use %s for %s", t, gtype.Name())
+	}
+
+	if ret {
+		v, err := GValue(reflect.Zero(t).Interface())
+		if err != nil {
+			return err
+		}
+		if actual, _, _ := v.Type(); !gobool(C.g_value_type_compatible(C.GType(actual), C.GType(gtype))) {
+			return fmt.Errorf("cannot return %s as %s", t, gtype.Name())
+		}
+		return nil
+	}
+
+	v, err := ValueInit(gtype)
+	if err != nil {
+		return err
+	}
+	goValue, err := v.GoValue()
+	if err != nil {
+		return err
+	}
+	gt := reflect.TypeOf(goValue)
+	if t.Kind() == reflect.Interface {
+		if !gt.Implements(t) {
+			return fmt.Errorf("%s does not implement %s", gt, t)
+		}
+		return nil
+	}
+	// numbers are convertible to strings, but that is never what the handler wants
+	if !gt.ConvertibleTo(t) || (gt.Kind() == reflect.String) != (t.Kind() == reflect.String) {
+		return fmt.Errorf("cannot use %s for %s", t, gtype.Name())
+	}
+	return nil
+}
+
+// closureFuncs maps the live closures created by ClosureNew to the code pointer of their function, so
+// that handlers connected from go can be found by their function. Closures are only removed from the
+// map in their finalize notifier, so they stay valid while closureFuncsMu is held.
+var (
+	closureFuncsMu sync.Mutex
+	closureFuncs   = make(map[*C.GClosure]uintptr)
+)
+
+// findClosure returns the first closure created from f for which match returns true.
+func findClosure[T comparable](f interface{}, match func(*C.GClosure) T) T {
+	var zero T
+
+	rf := reflect.ValueOf(f)
+	if rf.Kind() != reflect.Func {
+		return zero
+	}
+	fn := rf.Pointer()
+
+	closureFuncsMu.Lock()
+	defer closureFuncsMu.Unlock()
+
+	for closure, closureFn := range closureFuncs {
+		if closureFn != fn {
+			continue
+		}
+		if res := match(closure); res != zero {
+			return res
+		}
+	}
+	return zero
+}
+
 // ClosureNew creates a new GClosure with the given function f. The returned closure is floating. This
 // is useful so that the finalizer of the closure gets automatically called when the signal is disconnected.
 //
@@ -104,6 +255,10 @@ func ClosureNew(f interface{}, marshalData ...interface{}) (*C.GClosure, error)
 
 	closureProfile.Add(c, 2)
 
+	closureFuncsMu.Lock()
+	closureFuncs[c] = rf.Pointer()
+	closureFuncsMu.Unlock()
+
 	C.g_closure_ref(c)
 	C.g_closure_sink(c)
 
@@ -116,5 +271,9 @@ func ClosureNew(f interface{}, marshalData ...interface{}) (*C.GClosure, error)
 func removeClosure(ccHandle C.gpointer, closure *C.GClosure) {
 	closureProfile.Remove(closure)
 
+	closureFuncsMu.Lock()
+	delete(closureFuncs, closure)
+	closureFuncsMu.Unlock()
+
 	gopointer.Unref(unsafe.Pointer(ccHandle))
 }
@@ -6,10 +6,14 @@ package glib
 // #include "glib.go.h"
 import "C"
 import (
+	"context"
 	"errors"
 	"unsafe"
 )
 
+// ErrCancelled is the cause of contexts derived from a Cancellable that was cancelled.
+var ErrCancelled = errors.New("cancellable was cancelled")
+
 // Cancellable is a representation of GIO's GCancellable.
 type Cancellable struct {
 	*Object
@@ -41,6 +45,54 @@ func CancellableNew() (*Cancellable, error) {
 	return wrapCancellable(wrapObject(unsafe.Pointer(c))), nil
 }
 
+// CancellableNewWithContext creates a new Cancellable that is cancelled when ctx is done.
+// Until then ctx references the cancellable. Calling the returned release func stops
+// following ctx and drops that reference. It should be called once the cancellable is no
+// longer needed, unless ctx is known to be done eventually. Contexts that are never done,
+// like context.Background(), are not followed at all.
+func CancellableNewWithContext(ctx context.Context) (cancellable *Cancellable, release func(), err error) {
+	cancellable, err = CancellableNew()
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.Done() == nil {
+		return cancellable, func() {}, nil
+	}
+	stop := context.AfterFunc(ctx, cancellable.Cancel)
+	return cancellable, func() { stop() }, nil
+}
+
+// Context returns a copy of parent that is cancelled when the cancellable is, with ErrCancelled
+// as its cause, see context.Cause. Calling the returned CancelFunc cancels the context, but not
+// the cancellable, and releases the handler connected to it.
+func (v *Cancellable) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	handle, err := v.Connect("cancelled", func() { cancel(ErrCancelled) })
+	if err != nil {
+		cancel(err)
+		return ctx, func() {}
+	}
+	context.AfterFunc(ctx, func() { v.HandlerDisconnect(handle) })
+
+	// the cancellable may have been cancelled before the handler was connected
+	if v.IsCancelled() {
+		cancel(ErrCancelled)
+	}
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// Cancel is a wrapper around g_cancellable_cancel(). It can be called from any goroutine,
+// the "cancelled" signal is emitted on the calling thread.
+func (v *Cancellable) Cancel() {
+	C.g_cancellable_cancel(v.native())
+}
+
+// Reset is a wrapper around g_cancellable_reset().
+func (v *Cancellable) Reset() {
+	C.g_cancellable_reset(v.native())
+}
+
 // IsCancelled is a wrapper around g_cancellable_is_cancelled().
 func (v *Cancellable) IsCancelled() bool {
 	c := C.g_cancellable_is_cancelled(v.native())
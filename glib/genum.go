@@ -0,0 +1,125 @@
+package glib
+
+// #include "glib.go.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// goEnumTypes maps defined go types to the enum and flags types registered for them.
+var goEnumTypes = struct {
+	sync.RWMutex
+	m map[reflect.Type]Type
+}{
+	m: make(map[reflect.Type]Type),
+}
+
+// EnumValue is a go representation of GEnumValue
+type EnumValue struct {
+	Value                int
+	ValueName, ValueNick string
+}
+
+// RegisterEnumType is a wrapper around g_enum_register_static(). It registers a new GEnum type
+// with the given name and values and returns the new Type.
+//
+// T is associated with the new type: converting a value of T with GValue will create a Value
+// holding the enum, and GoValue on such a Value will return a T. This lets typed Go constants
+// round-trip through properties and signals. If T is a predeclared type like int, only the GType
+// is registered and GoValue returns an int.
+func RegisterEnumType[T constraints.Signed](name string, values []EnumValue) (Type, error) {
+	if TypeFromName(name) != TYPE_INVALID {
+		return TYPE_INVALID, fmt.Errorf("type %s is already registered", name)
+	}
+	if len(values) == 0 {
+		return TYPE_INVALID, errors.New("enum types need at least one value")
+	}
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	// the values must stay valid for the lifetime of the type, so they are never freed
+	cvalues := unsafe.Slice((*C.GEnumValue)(C.calloc(C.size_t(len(values)+1), C.sizeof_GEnumValue)), len(values)+1)
+	for i, v := range values {
+		cvalues[i].value = C.gint(v.Value)
+		cvalues[i].value_name = (*C.gchar)(C.CString(v.ValueName))
+		cvalues[i].value_nick = (*C.gchar)(C.CString(v.ValueNick))
+	}
+
+	gtype := Type(C.g_enum_register_static((*C.gchar)(cname), &cvalues[0]))
+	if gtype == TYPE_INVALID {
+		return TYPE_INVALID, fmt.Errorf("could not register enum type %s", name)
+	}
+
+	registerGoEnumType(reflect.TypeFor[T](), gtype, func(p unsafe.Pointer) (interface{}, error) {
+		return T(C.g_value_get_enum((*C.GValue)(p))), nil
+	})
+
+	return gtype, nil
+}
+
+// RegisterFlagsType is a wrapper around g_flags_register_static(). It registers a new GFlags type
+// with the given name and values and returns the new Type.
+//
+// T is associated with the new type the same way as in RegisterEnumType. GoValue returns a uint
+// if T is a predeclared type.
+func RegisterFlagsType[T constraints.Unsigned](name string, values []FlagsValue) (Type, error) {
+	if TypeFromName(name) != TYPE_INVALID {
+		return TYPE_INVALID, fmt.Errorf("type %s is already registered", name)
+	}
+	if len(values) == 0 {
+		return TYPE_INVALID, errors.New("flags types need at least one value")
+	}
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	// the values must stay valid for the lifetime of the type, so they are never freed
+	cvalues := unsafe.Slice((*C.GFlagsValue)(C.calloc(C.size_t(len(values)+1), C.sizeof_GFlagsValue)), len(values)+1)
+	for i, v := range values {
+		cvalues[i].value = C.guint(v.Value)
+		cvalues[i].value_name = (*C.gchar)(C.CString(v.ValueName))
+		cvalues[i].value_nick = (*C.gchar)(C.CString(v.ValueNick))
+	}
+
+	gtype := Type(C.g_flags_register_static((*C.gchar)(cname), &cvalues[0]))
+	if gtype == TYPE_INVALID {
+		return TYPE_INVALID, fmt.Errorf("could not register flags type %s", name)
+	}
+
+	registerGoEnumType(reflect.TypeFor[T](), gtype, func(p unsafe.Pointer) (interface{}, error) {
+		return T(C.g_value_get_flags((*C.GValue)(p))), nil
+	})
+
+	return gtype, nil
+}
+
+// registerGoEnumType associates the defined go type t with the enum or flags type gtype.
+// Predeclared types are skipped, since they already have a fixed GValue representation.
+func registerGoEnumType(t reflect.Type, gtype Type, marshaler GValueMarshaler) {
+	if t.PkgPath() == "" {
+		return
+	}
+
+	goEnumTypes.Lock()
+	goEnumTypes.m[t] = gtype
+	goEnumTypes.Unlock()
+
+	RegisterGValueMarshalers([]TypeMarshaler{{gtype, marshaler}})
+}
+
+// goEnumType returns the enum or flags type registered for the go type t.
+func goEnumType(t reflect.Type) (Type, bool) {
+	goEnumTypes.RLock()
+	defer goEnumTypes.RUnlock()
+
+	gtype, ok := goEnumTypes.m[t]
+	return gtype, ok
+}
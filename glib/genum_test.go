@@ -0,0 +1,111 @@
+package glib_test
+
+import (
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type testMode int
+
+const (
+	testModeA testMode = iota
+	testModeB
+)
+
+type testFlags uint
+
+const (
+	testFlagRead testFlags = 1 << iota
+	testFlagWrite
+)
+
+// registerTestMode registers testMode as GoGlibTestMode. Types stay registered when the tests
+// run again in the same process, so the existing type is reused.
+func registerTestMode(t *testing.T) glib.Type {
+	t.Helper()
+	typ := glib.TypeFromName("GoGlibTestMode")
+	if typ == glib.TYPE_INVALID {
+		var err error
+		typ, err = glib.RegisterEnumType[testMode]("GoGlibTestMode", []glib.EnumValue{
+			{Value: int(testModeA), ValueName: "Mode A", ValueNick: "a"},
+			{Value: int(testModeB), ValueName: "Mode B", ValueNick: "b"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return typ
+}
+
+// registerTestFlags registers testFlags as GoGlibTestFlags, see registerTestMode.
+func registerTestFlags(t *testing.T) glib.Type {
+	t.Helper()
+	typ := glib.TypeFromName("GoGlibTestFlags")
+	if typ == glib.TYPE_INVALID {
+		var err error
+		typ, err = glib.RegisterFlagsType[testFlags]("GoGlibTestFlags", []glib.FlagsValue{
+			{Value: int(testFlagRead), ValueName: "Read", ValueNick: "read"},
+			{Value: int(testFlagWrite), ValueName: "Write", ValueNick: "write"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return typ
+}
+
+func TestRegisterEnumType(t *testing.T) {
+	typ := registerTestMode(t)
+	if !typ.IsA(glib.TYPE_ENUM) {
+		t.Fatalf("expected an enum type, got %s", typ.Name())
+	}
+
+	v, err := glib.GValue(testModeB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, _, _ := v.Type(); actual != typ {
+		t.Fatalf("expected value of type %s, got %s", typ.Name(), actual.Name())
+	}
+
+	ret, err := v.GoValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode, ok := ret.(testMode); !ok || mode != testModeB {
+		t.Fatalf("expected testModeB, got %T %v", ret, ret)
+	}
+
+	param := glib.NewEnumParam("mode", "Mode", "The mode", typ, int(testModeA), glib.ParameterReadWrite)
+	values := param.GetEnumValues()
+	if len(values) != 2 || values[1].ValueNick != "b" {
+		t.Fatalf("unexpected enum values: %v", values)
+	}
+
+	if _, err := glib.RegisterEnumType[testMode]("GoGlibTestMode", nil); err == nil {
+		t.Fatal("expected an error when registering a type twice")
+	}
+}
+
+func TestRegisterFlagsType(t *testing.T) {
+	typ := registerTestFlags(t)
+
+	v, err := glib.GValue(testFlagRead | testFlagWrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ret, err := v.GoValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags, ok := ret.(testFlags); !ok || flags != testFlagRead|testFlagWrite {
+		t.Fatalf("expected read|write, got %T %v", ret, ret)
+	}
+
+	param := glib.NewFlagsParam("flags", "Flags", "The flags", typ, uint(testFlagRead), glib.ParameterReadWrite)
+	if len(param.GetFlagValues()) != 2 {
+		t.Fatal("unexpected flag values")
+	}
+}
@@ -28,8 +28,10 @@ import "C"
 import (
 	"errors"
 	"fmt"
-	"os"
+	"log"
 	"reflect"
+	"runtime/debug"
+	"sync/atomic"
 	"unsafe"
 
 	gopointer "github.com/go-gst/go-pointer"
@@ -96,6 +98,10 @@ const (
 
 var TYPE_SOCKET Type = Type(C.G_TYPE_SOCKET) // is function g_socket_get_type() inside macro, can't be const in Go
 
+var TYPE_STRV Type = Type(C.G_TYPE_STRV)                     // is function g_strv_get_type() inside macro
+var TYPE_VALUE_ARRAY Type = Type(C.g_value_array_get_type()) // G_TYPE_VALUE_ARRAY is a deprecated macro
+var TYPE_GTYPE Type = Type(C.g_gtype_get_type())             // is function g_gtype_get_type() inside macro
+
 // IsValue checks whether the passed in type can be used for g_value_init().
 func (t Type) IsValue() bool {
 	return gobool(C._g_type_is_value(C.GType(t)))
@@ -176,11 +182,69 @@ const (
 	APPLICATION_NON_UNIQUE           ApplicationFlags = C.G_APPLICATION_NON_UNIQUE
 )
 
+// ErrTooManyClosureArgs is reported when a closure takes more arguments than it is invoked with.
+var ErrTooManyClosureArgs = errors.New("too many closure args")
+
+// PanicError is reported to the MarshalErrorHandler when a go closure invoked from the GLib runtime
+// panics. The panic is recovered so it does not unwind through C frames.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string { return fmt.Sprintf("panic in callback: %v", e.Value) }
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// MarshalErrorHandler is called with errors that happen while invoking go closures from the GLib
+// runtime, for example when a signal handler takes arguments of the wrong type or panics.
+type MarshalErrorHandler func(err error)
+
+var marshalErrorHandler atomic.Pointer[MarshalErrorHandler]
+
+// SetMarshalErrorHandler sets the handler that is called with errors happening while invoking
+// go closures, including signal handlers and the functions passed to IdleAdd and TimeoutAdd.
+// Panics are passed as *PanicError. The handler may be called from any thread. Passing nil
+// restores the default handler, which logs the errors and the stack trace of panics.
+func SetMarshalErrorHandler(handler MarshalErrorHandler) {
+	if handler == nil {
+		marshalErrorHandler.Store(nil)
+		return
+	}
+	marshalErrorHandler.Store(&handler)
+}
+
+func reportMarshalError(err error) {
+	if handler := marshalErrorHandler.Load(); handler != nil {
+		(*handler)(err)
+		return
+	}
+	if panicErr, ok := err.(*PanicError); ok {
+		log.Printf("glib: %v\n%s", panicErr, panicErr.Stack)
+		return
+	}
+	log.Printf("glib: %v", err)
+}
+
+// recoverMarshalPanic reports a panic of a go closure instead of letting it unwind through C.
+func recoverMarshalPanic() {
+	if r := recover(); r != nil {
+		reportMarshalError(&PanicError{Value: r, Stack: debug.Stack()})
+	}
+}
+
 // goMarshal is called by the GLib runtime when a closure needs to be invoked.
 // The closure will be invoked with as many arguments as it can take, from 0 to
 // the full amount provided by the call. If the closure asks for more parameters
-// than there are to give, a warning is printed to stderr and the closure is
-// not run.
+// than there are to give, an error is passed to the MarshalErrorHandler and
+// the closure is not run. Panics are recovered and reported the same way, the
+// return value is left at its default then.
 //
 //export goMarshal
 func goMarshal(
@@ -191,6 +255,8 @@ func goMarshal(
 	invocationHint C.gpointer,
 	marshalData C.gpointer,
 ) {
+	defer recoverMarshalPanic()
+
 	// Get the context associated with this callback closure.
 	cc := gopointer.Restore(unsafe.Pointer(marshalData)).(*closureContext)
 
@@ -203,13 +269,12 @@ func goMarshal(
 	}
 
 	// Get number of parameters from the callback closure.  If this exceeds
-	// the total number of marshaled parameters, a warning will be printed
-	// to stderr, and the callback will not be run.
+	// the total number of marshaled parameters, an error is reported and the
+	// callback will not be run.
 	nCbParams := cc.rf.Type().NumIn()
 	if nCbParams > nTotalParams {
-		fmt.Fprintf(os.Stderr,
-			"too many closure args: have %d, max allowed %d\n",
-			nCbParams, nTotalParams)
+		reportMarshalError(fmt.Errorf("%w: have %d, max allowed %d",
+			ErrTooManyClosureArgs, nCbParams, nTotalParams))
 		return
 	}
 
@@ -223,8 +288,7 @@ func goMarshal(
 		v := &Value{&gValues[i]}
 		val, err := v.GoValue()
 		if err != nil {
-			fmt.Fprintf(os.Stderr,
-				"no suitable Go value for arg %d: %v\n", i, err)
+			reportMarshalError(fmt.Errorf("no suitable Go value for arg %d: %w", i, err))
 			return
 		}
 		// Parameters that are descendants of GObject come wrapped in another GObject.
@@ -236,10 +300,9 @@ func goMarshal(
 		case *Object:
 			innerVal, err := objVal.goValue()
 			if err != nil {
-				// print warning and leave val unchanged to preserve old
+				// report the error and leave val unchanged to preserve old
 				// behavior
-				fmt.Fprintf(os.Stderr,
-					"warning: no suitable Go value from object for arg %d: %v\n", i, err)
+				reportMarshalError(fmt.Errorf("no suitable Go value from object for arg %d: %w", i, err))
 			} else {
 				val = innerVal
 			}
@@ -258,9 +321,12 @@ func goMarshal(
 	// values, save the GValue equivalent of the first.
 	rv := cc.rf.Call(args)
 	if retValue != nil && len(rv) > 0 {
-		if g, err := GValue(rv[0].Interface()); err != nil {
-			fmt.Fprintf(os.Stderr,
-				"cannot save callback return value: %v", err)
+		g, err := GValue(rv[0].Interface())
+		if err == nil {
+			g, err = toAliasedType(g, Type(C._g_value_type(retValue)))
+		}
+		if err != nil {
+			reportMarshalError(fmt.Errorf("cannot save callback return value: %w", err))
 		} else {
 			C.g_value_copy(g.native(), retValue)
 		}
@@ -283,9 +349,27 @@ type SourceHandle uint
 // context.  After running once, the source func will be removed
 // from the main event loop, unless f returns a single bool true.
 //
-// This function will cause a panic when f eventually runs if the
-// types of args do not match those of f.
+// If the types of args do not match those of f, or f panics, the panic
+// is recovered and reported to the MarshalErrorHandler when f eventually
+// runs, and the source is removed.
 func IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
+	return idleAdd(nil, f, args...)
+}
+
+// TimeoutAdd adds an timeout source to the default main event loop
+// context.  After running once, the source func will be removed
+// from the main event loop, unless f returns a single bool true.
+//
+// If the types of args do not match those of f, or f panics, the panic
+// is recovered and reported to the MarshalErrorHandler when f eventually
+// runs, and the source is removed.
+// timeout is in milliseconds
+func TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	return timeoutAdd(nil, timeout, f, args...)
+}
+
+// idleAdd adds an idle source to ctx, or the default context if ctx is nil.
+func idleAdd(ctx *MainContext, f interface{}, args ...interface{}) (SourceHandle, error) {
 	// f must be a func with no parameters.
 	rf := reflect.ValueOf(f)
 	if rf.Type().Kind() != reflect.Func {
@@ -297,17 +381,11 @@ func IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
 	if idleSrc == nil {
 		return 0, errNilPtr
 	}
-	return sourceAttach(idleSrc, rf, args...)
+	return sourceAttach(ctx, idleSrc, rf, args...)
 }
 
-// TimeoutAdd adds an timeout source to the default main event loop
-// context.  After running once, the source func will be removed
-// from the main event loop, unless f returns a single bool true.
-//
-// This function will cause a panic when f eventually runs if the
-// types of args do not match those of f.
-// timeout is in milliseconds
-func TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+// timeoutAdd adds a timeout source to ctx, or the default context if ctx is nil.
+func timeoutAdd(ctx *MainContext, timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
 	// f must be a func with no parameters.
 	rf := reflect.ValueOf(f)
 	if rf.Type().Kind() != reflect.Func {
@@ -320,18 +398,19 @@ func TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle,
 		return 0, errNilPtr
 	}
 
-	return sourceAttach(timeoutSrc, rf, args...)
+	return sourceAttach(ctx, timeoutSrc, rf, args...)
 }
 
-// sourceAttach attaches a source to the default main loop context.
-func sourceAttach(src *C.struct__GSource, rf reflect.Value, args ...interface{}) (SourceHandle, error) {
+// sourceAttach attaches a source to ctx, or the default main loop context if ctx is nil.
+// The reference of the new source is passed to the context.
+func sourceAttach(ctx *MainContext, src *C.struct__GSource, rf reflect.Value, args ...interface{}) (SourceHandle, error) {
 	if src == nil {
 		return 0, errNilPtr
 	}
 
 	// rf must be a func with no parameters.
 	if rf.Type().Kind() != reflect.Func {
-		C.g_source_destroy(src)
+		C.g_source_unref(src)
 		return 0, errors.New("rf is not a function")
 	}
 
@@ -341,12 +420,15 @@ func sourceAttach(src *C.struct__GSource, rf reflect.Value, args ...interface{})
 	var closure *C.GClosure
 	closure, _ = ClosureNew(rf.Interface(), args...)
 
-	// Set closure to run as a callback when the idle source runs.
+	// Set closure to run as a callback when the idle source runs,
+	// the source holds its own reference to it.
 	C.g_source_set_closure(src, closure)
+	C.g_closure_unref(closure)
 
-	// Attach the idle source func to the default main event loop
-	// context.
-	cid := C.g_source_attach(src, nil)
+	// Attach the idle source func to the main event loop context,
+	// which keeps it alive until it is removed.
+	cid := C.g_source_attach(src, ctx.native())
+	C.g_source_unref(src)
 	return SourceHandle(cid), nil
 }
 
@@ -483,8 +565,32 @@ func (s *Signal) String() string {
 	return s.name
 }
 
+// ID returns the id of the signal.
+func (s *Signal) ID() uint {
+	return uint(s.signalId)
+}
+
+func (s *Signal) id() C.guint {
+	if s == nil {
+		return 0
+	}
+	return s.signalId
+}
+
 type Quark uint32
 
+// QuarkFromString is a wrapper around g_quark_from_string().
+func QuarkFromString(s string) Quark {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+	return Quark(C.g_quark_from_string((*C.gchar)(cstr)))
+}
+
+// String is a wrapper around g_quark_to_string().
+func (q Quark) String() string {
+	return C.GoString((*C.char)(C.g_quark_to_string(C.GQuark(q))))
+}
+
 // GetApplicationName is a wrapper around g_get_application_name().
 func GetApplicationName() string {
 	c := C.g_get_application_name()
@@ -85,6 +85,10 @@ static GType _g_type_from_instance(gpointer instance) {
   return (G_TYPE_FROM_INSTANCE(instance));
 }
 
+static GType _g_type_from_class(gpointer g_class) {
+  return (G_TYPE_FROM_CLASS(g_class));
+}
+
 /* Wrapper to avoid variable arg list */
 static void _g_object_set_one(gpointer object, const gchar *property_name,
                               void *val) {
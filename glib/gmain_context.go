@@ -1,11 +1,29 @@
 package glib
 
-// #include <gio/gio.h>
-// #include <glib.h>
-// #include <glib-object.h>
-// #include "glib.go.h"
+/*
+#include <gio/gio.h>
+#include <glib.h>
+#include <glib-object.h>
+#include "glib.go.h"
+
+extern gboolean goMainContextInvoke        (gpointer data);
+extern void     goMainContextInvokeDestroy (gpointer data);
+
+static void _g_main_context_invoke (GMainContext * context, gpointer data)
+{
+	g_main_context_invoke_full(context, G_PRIORITY_DEFAULT, goMainContextInvoke, data, goMainContextInvokeDestroy);
+}
+*/
 import "C"
 
+import (
+	"context"
+	"runtime/debug"
+	"sync/atomic"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
 type MainContext C.GMainContext
 
 // native returns a pointer to the underlying GMainContext.
@@ -25,6 +43,139 @@ func MainContextDefault() *MainContext {
 	return (*MainContext)(c)
 }
 
+// MainContextNew is a wrapper around g_main_context_new(). The returned context
+// must be released with Unref.
+func MainContextNew() *MainContext {
+	return (*MainContext)(C.g_main_context_new())
+}
+
+// MainContextGetThreadDefault is a wrapper around g_main_context_get_thread_default().
+// It returns the context pushed with PushThreadDefault on the current thread, or nil if
+// the thread uses the global default context. Callers should lock their goroutine to its
+// thread with runtime.LockOSThread.
+func MainContextGetThreadDefault() *MainContext {
+	c := C.g_main_context_get_thread_default()
+	if c == nil {
+		return nil
+	}
+	return (*MainContext)(c)
+}
+
+// Ref is a wrapper around g_main_context_ref().
+func (v *MainContext) Ref() *MainContext {
+	return (*MainContext)(C.g_main_context_ref(v.native()))
+}
+
+// Unref is a wrapper around g_main_context_unref().
+func (v *MainContext) Unref() {
+	C.g_main_context_unref(v.native())
+}
+
+// PushThreadDefault is a wrapper around g_main_context_push_thread_default(). It makes
+// v the default context of the current thread, so sources created by other libraries on
+// this thread are attached to it, until PopThreadDefault is called on the same thread.
+// The goroutine must be locked to its thread with runtime.LockOSThread until then.
+func (v *MainContext) PushThreadDefault() {
+	C.g_main_context_push_thread_default(v.native())
+}
+
+// PopThreadDefault is a wrapper around g_main_context_pop_thread_default().
+func (v *MainContext) PopThreadDefault() {
+	C.g_main_context_pop_thread_default(v.native())
+}
+
+// IsOwner is a wrapper around g_main_context_is_owner(). It returns true if the
+// context is acquired by the current thread, e.g. while a MainLoop runs it.
+func (v *MainContext) IsOwner() bool {
+	return gobool(C.g_main_context_is_owner(v.native()))
+}
+
+// Wakeup is a wrapper around g_main_context_wakeup(). It wakes up a thread blocked
+// in an iteration of the context.
+func (v *MainContext) Wakeup() {
+	C.g_main_context_wakeup(v.native())
+}
+
+// IdleAdd adds an idle source to the context, see the package level IdleAdd.
+func (v *MainContext) IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
+	return idleAdd(v, f, args...)
+}
+
+// TimeoutAdd adds a timeout source to the context, see the package level TimeoutAdd.
+// timeout is in milliseconds
+func (v *MainContext) TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	return timeoutAdd(v, timeout, f, args...)
+}
+
+// RemoveSource destroys the source with the given handle attached to the context.
+// It returns false if there is no such source. Use this instead of SourceRemove,
+// which only finds sources of the global default context.
+func (v *MainContext) RemoveSource(hdlSrc SourceHandle) bool {
+	src := v.FindSourceById(hdlSrc)
+	if src == nil {
+		return false
+	}
+	src.Destroy()
+	return true
+}
+
+// Invoke is a wrapper around g_main_context_invoke_full(). It runs f on the thread owning
+// the context. If the calling thread owns the context, or can acquire it because it is the
+// thread default context, f runs before Invoke returns. Otherwise it runs in the next iteration
+// of the context. A nil context is the global default context.
+//
+// Panics in f are recovered and reported to the MarshalErrorHandler.
+func (v *MainContext) Invoke(f func()) {
+	C._g_main_context_invoke(v.native(), C.gpointer(gopointer.Save(f)))
+}
+
+// MainContextCall runs f on the thread owning mainCtx like MainContext.Invoke, blocks until
+// it returned and passes on its results. If ctx is done before f started, f is not run and the
+// error of ctx is returned. Once f started, MainContextCall waits for it to return even if ctx
+// is done meanwhile. Panics in f are returned as a *PanicError.
+func MainContextCall[T any](ctx context.Context, mainCtx *MainContext, f func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+
+	const (
+		pending = iota
+		started
+		abandoned
+	)
+	var state atomic.Int32
+
+	mainCtx.Invoke(func() {
+		if ctx.Err() != nil || !state.CompareAndSwap(pending, started) {
+			return
+		}
+
+		var res result
+		defer func() {
+			if r := recover(); r != nil {
+				res.err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+			done <- res
+		}()
+		res.value, res.err = f()
+	})
+
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-ctx.Done():
+		if state.CompareAndSwap(pending, abandoned) {
+			var zero T
+			return zero, ctx.Err()
+		}
+		// f started before ctx was done
+		res := <-done
+		return res.value, res.err
+	}
+}
+
 // Iteration is a wrapper around g_main_context_iteration()
 func (v *MainContext) Iteration(mayBlock bool) bool {
 	return gobool(C.g_main_context_iteration(v.native(), gbool(mayBlock)))
@@ -0,0 +1,25 @@
+package glib
+
+// #include "glib.go.h"
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+//export goMainContextInvoke
+func goMainContextInvoke(data C.gpointer) C.gboolean {
+	defer recoverMarshalPanic()
+
+	f := gopointer.Restore(unsafe.Pointer(data)).(func())
+	f()
+
+	return C.G_SOURCE_REMOVE
+}
+
+//export goMainContextInvokeDestroy
+func goMainContextInvokeDestroy(data C.gpointer) {
+	gopointer.Unref(unsafe.Pointer(data))
+}
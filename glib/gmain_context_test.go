@@ -0,0 +1,240 @@
+package glib_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+func TestMainContextSources(t *testing.T) {
+	ctx := glib.MainContextNew()
+	defer ctx.Unref()
+
+	var idleRuns, timeoutRuns int
+	if _, err := ctx.IdleAdd(func() { idleRuns++ }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.TimeoutAdd(0, func() { timeoutRuns++ }); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := ctx.IdleAdd(func() { t.Error("removed source ran") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ctx.RemoveSource(removed) || ctx.RemoveSource(removed) {
+		t.Fatal("expected the source to be removed once")
+	}
+
+	for glib.MainContextDefault().Iteration(false) {
+	}
+	if idleRuns != 0 || timeoutRuns != 0 {
+		t.Fatal("expected the sources not to run on the default context")
+	}
+
+	for idleRuns == 0 || timeoutRuns == 0 {
+		ctx.Iteration(true)
+	}
+	for ctx.Iteration(false) {
+	}
+	if idleRuns != 1 || timeoutRuns != 1 {
+		t.Fatalf("expected the sources to run once, got %d and %d", idleRuns, timeoutRuns)
+	}
+}
+
+func TestSourceRelease(t *testing.T) {
+	ctx := glib.MainContextNew()
+	defer ctx.Unref()
+
+	released := make(chan struct{})
+	isReleased := func() bool {
+		select {
+		case <-released:
+			return true
+		default:
+			return false
+		}
+	}
+
+	func() {
+		captured := new([64]byte)
+		runtime.SetFinalizer(captured, func(*[64]byte) { close(released) })
+		if _, err := ctx.IdleAdd(func() { _ = captured[0] }); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for ctx.Iteration(false) {
+	}
+
+	// the removed source frees its closure, which releases f
+	if !collectUntil(isReleased) {
+		t.Fatal("expected the function of a removed source to be released")
+	}
+}
+
+func TestMainContextThreadDefault(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if glib.MainContextGetThreadDefault() != nil {
+		t.Fatal("expected no thread default context")
+	}
+
+	ctx := glib.MainContextNew()
+	defer ctx.Unref()
+
+	ctx.PushThreadDefault()
+	if glib.MainContextGetThreadDefault() != ctx {
+		t.Fatal("expected the pushed context to be the thread default")
+	}
+	ctx.PopThreadDefault()
+
+	if glib.MainContextGetThreadDefault() != nil {
+		t.Fatal("expected no thread default context after popping")
+	}
+}
+
+func TestMainContextCall(t *testing.T) {
+	mainCtx := glib.MainContextNew()
+	defer mainCtx.Unref()
+
+	loop := glib.NewMainLoop(mainCtx, false)
+	loopThread := make(chan bool, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		mainCtx.PushThreadDefault()
+		defer mainCtx.PopThreadDefault()
+
+		// inline, as the context is the thread default and not owned by another thread
+		ran := false
+		mainCtx.Invoke(func() { ran = true })
+		loopThread <- ran
+
+		loop.Run()
+	}()
+	if !<-loopThread {
+		t.Fatal("expected Invoke to run inline on the thread default context")
+	}
+	defer loop.Quit()
+
+	n, err := glib.MainContextCall(context.Background(), mainCtx, func() (int, error) {
+		if !mainCtx.IsOwner() {
+			return 0, errors.New("not called on the thread owning the context")
+		}
+		return 42, nil
+	})
+	if err != nil || n != 42 {
+		t.Fatalf("expected 42, got %d (%v)", n, err)
+	}
+
+	_, err = glib.MainContextCall(context.Background(), mainCtx, func() (struct{}, error) {
+		panic("boom")
+	})
+	var panicErr *glib.PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("expected the panic to be returned, got %v", err)
+	}
+
+	// a call that started is waited for even if its context is done meanwhile
+	ctx, cancel := context.WithCancel(context.Background())
+	n, err = glib.MainContextCall(ctx, mainCtx, func() (int, error) {
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		return 7, nil
+	})
+	if err != nil || n != 7 {
+		t.Fatalf("expected 7, got %d (%v)", n, err)
+	}
+
+	// a context that is never iterated
+	idle := glib.MainContextNew()
+	defer idle.Unref()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := glib.MainContextCall(ctx, idle, func() (bool, error) { return true, nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
+
+func TestMainLoopRunContext(t *testing.T) {
+	mainCtx := glib.MainContextNew()
+	defer mainCtx.Unref()
+	loop := glib.NewMainLoop(mainCtx, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := loop.RunContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if err := loop.RunContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a done context not to run the loop, got %v", err)
+	}
+
+	if _, err := mainCtx.IdleAdd(loop.Quit); err != nil {
+		t.Fatal(err)
+	}
+	if err := loop.RunContext(context.Background()); err != nil {
+		t.Fatalf("expected the loop to quit without error, got %v", err)
+	}
+}
+
+func TestCancellableContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancellable, release, err := glib.CancellableNewWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if cancellable.IsCancelled() {
+		t.Fatal("expected the cancellable not to be cancelled yet")
+	}
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for !cancellable.IsCancelled() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the cancellable to be cancelled with its context")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// a released cancellable no longer follows its context
+	ctx, cancel = context.WithCancel(context.Background())
+	cancellable, release, err = glib.CancellableNewWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if cancellable.IsCancelled() {
+		t.Fatal("expected the released cancellable not to be cancelled")
+	}
+
+	cancellable, err = glib.CancellableNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, stop := cancellable.Context(context.Background())
+	defer stop()
+	cancellable.Cancel()
+	select {
+	case <-derived.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the context to be done after cancelling")
+	}
+	if !errors.Is(context.Cause(derived), glib.ErrCancelled) {
+		t.Fatalf("expected ErrCancelled as cause, got %v", context.Cause(derived))
+	}
+
+	// contexts of cancelled cancellables are done immediately
+	derived, stop = cancellable.Context(context.Background())
+	defer stop()
+	if derived.Err() == nil {
+		t.Fatal("expected the context of a cancelled cancellable to be done")
+	}
+}
@@ -4,7 +4,11 @@ package glib
 #include "glib.go.h"
 */
 import "C"
-import "runtime"
+
+import (
+	"context"
+	"runtime"
+)
 
 // MainLoop is a go representation of a GMainLoop. It can be used to block execution
 // while a pipeline is running, and also allows for event sources and signals to be used
@@ -60,6 +64,38 @@ func (m *MainLoop) RunError() error {
 	return nil
 }
 
+// RunContext runs the main loop like Run until Quit is called or ctx is done. It returns the
+// error of ctx if ctx is done, and nil otherwise. If ctx is already done the loop is not run.
+func (m *MainLoop) RunContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mainCtx := m.GetContext()
+	stop := make(chan struct{})
+	quitSources := make(chan SourceHandle, 1)
+	go func() {
+		defer close(quitSources)
+		select {
+		case <-ctx.Done():
+			// quit from within the loop, as a Quit before the loop started running would be lost
+			if handle, err := mainCtx.IdleAdd(m.Quit); err == nil {
+				quitSources <- handle
+			}
+		case <-stop:
+		}
+	}()
+
+	m.Run()
+
+	// remove the quit source if the loop was quit otherwise, so it does not stop the next run
+	close(stop)
+	for handle := range quitSources {
+		mainCtx.RemoveSource(handle)
+	}
+	return ctx.Err()
+}
+
 // Quit stops a MainLoop from running. Any calls to Run() for the loop will return. Note that
 // sources that have already been dispatched when Quit() is called will still be executed.
 func (m *MainLoop) Quit() { C.g_main_loop_quit(m.Instance()) }
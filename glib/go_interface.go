@@ -0,0 +1,259 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+// The vtable of interfaces registered from go. Every method is a closure that gets
+// invoked with the instance as the first parameter.
+typedef struct {
+	GTypeInterface   parent;
+	GClosure        *methods[];
+} GoInterfaceVTable;
+
+static GClosure ** goInterfaceMethods (gpointer iface) { return ((GoInterfaceVTable *) iface)->methods; }
+
+static gpointer goInterfacePeekInstance (gpointer instance, GType iface_type)
+{
+	return g_type_interface_peek(G_OBJECT_GET_CLASS(instance), iface_type);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+// InterfaceMethod describes a virtual method of an interface registered with RegisterInterface.
+type InterfaceMethod struct {
+	// Name is the name of the method. Go types implementing the interface provide a method with this name.
+	Name string
+	// ParamTypes are the types of the parameters passed after the instance.
+	ParamTypes []Type
+	// ReturnType is the type of the return value, or TYPE_NONE.
+	ReturnType Type
+}
+
+// GoInterface is a GInterface registered from go with RegisterInterface. It implements Interface, so
+// it can be passed to RegisterGoType to implement it on a Go type.
+type GoInterface struct {
+	gtype   Type
+	methods []InterfaceMethod
+}
+
+var _ Interface = (*GoInterface)(nil)
+
+// RegisterInterface registers a new GInterface with the given name and virtual methods. The instances
+// implementing it must be of all prerequisites, TYPE_OBJECT is used if none are given.
+//
+// The vtable of the interface is a GTypeInterface followed by one GClosure pointer per method, in the
+// order they are given. C types can implement the interface by storing closures in the vtable
+// during their interface_init. Go types pass the returned GoInterface to RegisterGoType and provide
+// methods with the same names, taking the parameters after the instance.
+func RegisterInterface(name string, methods []InterfaceMethod, prerequisites ...Type) (*GoInterface, error) {
+	if TypeFromName(name) != TYPE_INVALID {
+		return nil, fmt.Errorf("type %s is already registered", name)
+	}
+
+	typeInfo := (*C.GTypeInfo)(C.calloc(1, C.sizeof_GTypeInfo))
+	defer C.free(unsafe.Pointer(typeInfo))
+
+	typeInfo.class_size = C.guint16(C.sizeof_GTypeInterface + C.sizeof_gpointer*C.ulong(len(methods)))
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	gtype := C.g_type_register_static(C.G_TYPE_INTERFACE, (*C.gchar)(cName), typeInfo, C.GTypeFlags(0))
+	if gtype == 0 {
+		return nil, fmt.Errorf("could not register interface %s", name)
+	}
+
+	if len(prerequisites) == 0 {
+		prerequisites = []Type{TYPE_OBJECT}
+	}
+	for _, prerequisite := range prerequisites {
+		C.g_type_interface_add_prerequisite(gtype, C.GType(prerequisite))
+	}
+
+	for i := range methods {
+		if methods[i].ReturnType == TYPE_INVALID {
+			methods[i].ReturnType = TYPE_NONE
+		}
+	}
+
+	return &GoInterface{
+		gtype:   Type(gtype),
+		methods: methods,
+	}, nil
+}
+
+// Type returns the GType of the interface.
+func (i *GoInterface) Type() Type { return i.gtype }
+
+// Methods returns the virtual methods of the interface.
+func (i *GoInterface) Methods() []InterfaceMethod { return i.methods }
+
+// Validate checks that the methods of goObject implementing methods of the interface take the
+// parameters and return the value the interface defines. RegisterGoType panics with this error, so
+// Validate can be used to handle it before registering a type.
+func (i *GoInterface) Validate(goObject interface{}) error {
+	goType := reflect.TypeOf(goObject)
+	for _, method := range i.methods {
+		goMethod, ok := goType.MethodByName(method.Name)
+		if !ok {
+			continue
+		}
+		if err := i.validateMethod(method, goMethod.Type); err != nil {
+			return fmt.Errorf("method %s of %s: %w", method.Name, goType, err)
+		}
+	}
+	return nil
+}
+
+// validateMethod checks the type of a go method, including its receiver, against method.
+func (i *GoInterface) validateMethod(method InterfaceMethod, ft reflect.Type) error {
+	if ft.IsVariadic() || ft.NumIn()-1 != len(method.ParamTypes) {
+		return fmt.Errorf("takes %d parameters, the interface %s defines %d", ft.NumIn()-1, i.gtype.Name(), len(method.ParamTypes))
+	}
+	for p, gtype := range method.ParamTypes {
+		if err := checkHandlerType(gtype, ft.In(p+1), false); err != nil {
+			return fmt.Errorf("parameter %d: %w", p, err)
+		}
+	}
+
+	if method.ReturnType == TYPE_NONE {
+		if ft.NumOut() != 0 {
+			return errors.New("must not return a value")
+		}
+		return nil
+	}
+	if ft.NumOut() != 1 {
+		return fmt.Errorf("must return a single %s", method.ReturnType.Name())
+	}
+	if err := checkHandlerType(method.ReturnType, ft.Out(0), true); err != nil {
+		return fmt.Errorf("return value: %w", err)
+	}
+	return nil
+}
+
+// Init fills the vtable of the interface with closures calling the methods of the go type. Methods
+// the go type does not provide are left empty. The go type was checked with Validate when it was
+// registered, methods failing that check are reported to the MarshalErrorHandler and left empty.
+func (i *GoInterface) Init(instance *TypeInstance) {
+	goType := reflect.TypeOf(instance.GoType)
+	vtable := unsafe.Slice(C.goInterfaceMethods(C.gpointer(instance.GTypeInstance)), len(i.methods))
+	iobjectType := reflect.TypeFor[IObject]()
+
+	for idx, method := range i.methods {
+		goMethod, ok := goType.MethodByName(method.Name)
+		if !ok {
+			continue
+		}
+		if err := i.validateMethod(method, goMethod.Type); err != nil {
+			reportMarshalError(fmt.Errorf("method %s of %s: %w", method.Name, goType, err))
+			continue
+		}
+
+		// the method type includes the receiver
+		in := []reflect.Type{iobjectType}
+		for p := 1; p < goMethod.Type.NumIn(); p++ {
+			in = append(in, goMethod.Type.In(p))
+		}
+		out := make([]reflect.Type, 0, goMethod.Type.NumOut())
+		for p := 0; p < goMethod.Type.NumOut(); p++ {
+			out = append(out, goMethod.Type.Out(p))
+		}
+
+		name := method.Name
+		f := reflect.MakeFunc(reflect.FuncOf(in, out, false), func(args []reflect.Value) []reflect.Value {
+			obj := args[0].Interface().(IObject).toObject()
+			goObject := FromObjectUnsafePrivate(obj.Unsafe())
+			return reflect.ValueOf(goObject).MethodByName(name).Call(args[1:])
+		})
+
+		// the closure is kept for the lifetime of the class, like the class itself
+		closure, err := ClosureNew(f.Interface())
+		if err != nil {
+			reportMarshalError(fmt.Errorf("method %s of %s: %w", method.Name, goType, err))
+			continue
+		}
+		vtable[idx] = closure
+	}
+}
+
+// Call invokes the method with the given name on obj through the vtable of the interface. obj must
+// implement the interface. The args are converted to GValues and must match the parameter types
+// of the method, the return value is converted back with GoValue.
+func (i *GoInterface) Call(obj IObject, method string, args ...interface{}) (interface{}, error) {
+	idx := -1
+	for m := range i.methods {
+		if i.methods[m].Name == method {
+			idx = m
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("interface %s has no method %s", i.gtype.Name(), method)
+	}
+	def := i.methods[idx]
+
+	object := obj.toObject()
+	if !object.IsA(i.gtype) {
+		return nil, fmt.Errorf("%s does not implement %s", object.TypeFromInstance().Name(), i.gtype.Name())
+	}
+
+	if len(args) != len(def.ParamTypes) {
+		return nil, fmt.Errorf("%w for method %s: expected %d, got %d", ErrSignalWrongNumberOfArgs, method, len(def.ParamTypes), len(args))
+	}
+
+	iface := C.goInterfacePeekInstance(C.gpointer(object.Unsafe()), C.GType(i.gtype))
+	if iface == nil {
+		return nil, errors.New("could not find interface vtable")
+	}
+	closure := unsafe.Slice(C.goInterfaceMethods(iface), len(i.methods))[idx]
+	if closure == nil {
+		return nil, fmt.Errorf("%s does not implement method %s", object.TypeFromInstance().Name(), method)
+	}
+
+	params := make([]C.GValue, len(args)+1)
+
+	instanceValue, err := GValue(object)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Object to GValue: %w", err)
+	}
+	params[0] = *instanceValue.native()
+	defer runtime.KeepAlive(instanceValue)
+
+	for n, arg := range args {
+		v, err := GValue(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error converting arg %d to GValue: %w", n, err)
+		}
+		actual, fundamental, _ := v.Type()
+		if actual != def.ParamTypes[n] && fundamental != def.ParamTypes[n] {
+			return nil, fmt.Errorf("argument %d has wrong type, expected %s, got %s", n, def.ParamTypes[n].Name(), actual.Name())
+		}
+		params[n+1] = *v.native()
+		defer runtime.KeepAlive(v)
+	}
+
+	if def.ReturnType == TYPE_NONE {
+		C.g_closure_invoke(closure, nil, C.guint(len(params)), &params[0], nil)
+		return nil, nil
+	}
+
+	ret, err := ValueInit(def.ReturnType)
+	if err != nil {
+		return nil, errors.New("error creating Value for return value")
+	}
+	C.g_closure_invoke(closure, ret.native(), C.guint(len(params)), &params[0], nil)
+
+	// implementations from C may unset or replace the return value
+	if actual, _, err := ret.Type(); err != nil || actual != def.ReturnType {
+		return nil, fmt.Errorf("method %s did not return a %s", method, def.ReturnType.Name())
+	}
+	return ret.GoValue()
+}
@@ -0,0 +1,97 @@
+package glib_test
+
+import (
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type interfaceTestObject struct{ greeting string }
+
+func (o *interfaceTestObject) New() glib.GoObjectSubclass {
+	return &interfaceTestObject{greeting: "hello"}
+}
+
+func (o *interfaceTestObject) ClassInit(*glib.ObjectClass) {}
+
+func (o *interfaceTestObject) Greet(name string) string { return o.greeting + " " + name }
+
+// greeterInterface is registered once, as types stay registered when the test runs again in the
+// same process.
+var greeterInterface *glib.GoInterface
+
+func registerGreeterInterface(t *testing.T) *glib.GoInterface {
+	t.Helper()
+	if greeterInterface == nil {
+		var err error
+		greeterInterface, err = glib.RegisterInterface("GoGlibTestGreeter", []glib.InterfaceMethod{
+			{Name: "Greet", ParamTypes: []glib.Type{glib.TYPE_STRING}, ReturnType: glib.TYPE_STRING},
+			{Name: "Wave"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return greeterInterface
+}
+
+func TestRegisterInterface(t *testing.T) {
+	iface := registerGreeterInterface(t)
+
+	typ := glib.RegisterGoType("GoGlibInterfaceTestObject", &interfaceTestObject{}, glib.ExtendsObject, iface)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !obj.IsA(iface.Type()) {
+		t.Fatal("expected object to implement the interface")
+	}
+
+	ret, err := iface.Call(obj, "Greet", "world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret != "hello world" {
+		t.Fatalf("expected hello world, got %v", ret)
+	}
+
+	if _, err := iface.Call(obj, "Wave"); err == nil {
+		t.Fatal("expected an error for a method that is not implemented")
+	}
+	if _, err := iface.Call(obj, "Greet", 1); err == nil {
+		t.Fatal("expected an error for an argument of the wrong type")
+	}
+}
+
+type extraParamGreeter struct{ interfaceTestObject }
+
+func (o *extraParamGreeter) Greet(name string, times int) string { return name }
+
+type wrongReturnGreeter struct{ interfaceTestObject }
+
+func (o *wrongReturnGreeter) Greet(name string) int { return len(name) }
+
+type returningWaver struct{ interfaceTestObject }
+
+func (o *returningWaver) Wave() bool { return true }
+
+func TestValidateInterface(t *testing.T) {
+	iface := registerGreeterInterface(t)
+
+	if err := iface.Validate(&interfaceTestObject{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, goObject := range []glib.GoObjectSubclass{&extraParamGreeter{}, &wrongReturnGreeter{}, &returningWaver{}} {
+		if err := iface.Validate(goObject); err == nil {
+			t.Fatalf("expected an error for %T", goObject)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected RegisterGoType to panic")
+		}
+	}()
+	glib.RegisterGoType("GoGlibInvalidGreeter", &wrongReturnGreeter{}, glib.ExtendsObject, iface)
+}
@@ -6,6 +6,7 @@ package glib
 extern void   goObjectSetProperty  (GObject * object, guint property_id, const GValue * value, GParamSpec *pspec);
 extern void   goObjectGetProperty  (GObject * object, guint property_id, GValue * value, GParamSpec * pspec);
 extern void   goObjectConstructed  (GObject * object);
+extern void   goObjectDispose      (GObject * object);
 extern void   goObjectFinalize     (GObject * object, gpointer klass);
 
 extern void   goClassInit     (gpointer g_class, gpointer class_data);
@@ -19,6 +20,13 @@ void objectFinalize (GObject * object)
 	parent->finalize(object);
 }
 
+void objectDispose (GObject * object)
+{
+	GObjectClass *parent = g_type_class_peek_parent((G_OBJECT_GET_CLASS(object)));
+	goObjectDispose(object);
+	parent->dispose(object);
+}
+
 void objectConstructed (GObject * object)
 {
 	GObjectClass *parent = g_type_class_peek_parent((G_OBJECT_GET_CLASS(object)));
@@ -29,6 +37,7 @@ void objectConstructed (GObject * object)
 void  setGObjectClassSetProperty  (void * klass)  { ((GObjectClass *)klass)->set_property = goObjectSetProperty; }
 void  setGObjectClassGetProperty  (void * klass)  { ((GObjectClass *)klass)->get_property = goObjectGetProperty; }
 void  setGObjectClassConstructed  (void * klass)  { ((GObjectClass *)klass)->constructed = objectConstructed; }
+void  setGObjectClassDispose      (void * klass)  { ((GObjectClass *)klass)->dispose = objectDispose; }
 void  setGObjectClassFinalize     (void * klass)  { ((GObjectClass *)klass)->finalize = objectFinalize; }
 
 void  cgoClassInit      (gpointer g_class, gpointer class_data)       { goClassInit(g_class, class_data); }
@@ -38,6 +47,7 @@ void  cgoInterfaceInit  (gpointer iface, gpointer iface_data)         { goInterf
 */
 import "C"
 import (
+	"fmt"
 	"reflect"
 	"unsafe"
 
@@ -73,6 +83,20 @@ type Initter interface {
 	InstanceInit(*Object)
 }
 
+// Disposer is an interface that can be implemented on top of a GoObjectSubclass. Dispose is called when the
+// GObject is disposed, before it is finalized. It should drop all references to other objects to break
+// reference cycles. Dispose may be called more than once and the object may still be used afterwards.
+type Disposer interface {
+	Dispose(*Object)
+}
+
+// Finalizer is an interface that can be implemented on top of a GoObjectSubclass. Finalize is called once
+// when the GObject is finalized, right before the memory of the instance is freed. The object must not
+// be used after Finalize returns.
+type Finalizer interface {
+	Finalize(*Object)
+}
+
 // TypeInstance is a loose binding around the glib GTypeInstance. It holds the information required to assign
 // various capabilities of a GoObjectSubclass.
 type TypeInstance struct {
@@ -153,7 +177,8 @@ type classData struct {
 //
 // Interfaces are optional and flags additional interfaces as implemented on the class. Similar to the
 // extendables, libraries using these bindings can implement the Interface interface to provide support
-// for other GInterfaces.
+// for other GInterfaces. It panics if goObject does not implement the methods of a GoInterface as
+// defined, see GoInterface.Validate.
 func RegisterGoType(name string, goObject interface{}, extends Extendable, interfaces ...Interface) Type {
 	registerMutex.Lock()
 	defer registerMutex.Unlock()
@@ -161,6 +186,14 @@ func RegisterGoType(name string, goObject interface{}, extends Extendable, inter
 		return registered
 	}
 
+	for _, iface := range interfaces {
+		if goIface, ok := iface.(*GoInterface); ok {
+			if err := goIface.Validate(goObject); err != nil {
+				panic(fmt.Sprintf("cannot register %s: %v", name, err))
+			}
+		}
+	}
+
 	typeInfo := (*C.GTypeInfo)(C.malloc(C.sizeof_GTypeInfo))
 	defer C.free(unsafe.Pointer(typeInfo))
 
@@ -258,6 +291,9 @@ func (e *extendObject) InstanceSize() int64 { return int64(C.sizeof_GObject) }
 func (e *extendObject) InitClass(klass unsafe.Pointer, elem GoObjectSubclass) {
 	C.setGObjectClassFinalize(klass)
 
+	if _, ok := elem.(Disposer); ok {
+		C.setGObjectClassDispose(klass)
+	}
 	if _, ok := elem.(interface {
 		SetProperty(obj *Object, id uint, value *Value)
 	}); ok {
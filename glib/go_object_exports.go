@@ -18,6 +18,11 @@ func goObjectSetProperty(obj *C.GObject, propID C.guint, val *C.GValue, param *C
 	object := wrapObjectClean(unsafe.Pointer(obj))
 	subclass := FromObjectUnsafePrivate(unsafe.Pointer(obj))
 
+	if prop, ok := lookupStructProperty(param); ok {
+		setStructProperty(object, subclass, prop, param, ValueFromNative(unsafe.Pointer(val)))
+		return
+	}
+
 	iface := subclass.(interface{ SetProperty(*Object, uint, *Value) })
 	iface.SetProperty(object, uint(propID-1), ValueFromNative(unsafe.Pointer(val)))
 }
@@ -27,8 +32,13 @@ func goObjectGetProperty(obj *C.GObject, propID C.guint, value *C.GValue, param
 	object := wrapObjectClean(unsafe.Pointer(obj))
 	subclass := FromObjectUnsafePrivate(unsafe.Pointer(obj))
 
-	iface := subclass.(interface{ GetProperty(*Object, uint) *Value })
-	val := iface.GetProperty(object, uint(propID-1))
+	var val *Value
+	if prop, ok := lookupStructProperty(param); ok {
+		val = getStructProperty(object, subclass, prop, param)
+	} else {
+		iface := subclass.(interface{ GetProperty(*Object, uint) *Value })
+		val = iface.GetProperty(object, uint(propID-1))
+	}
 	if val == nil {
 		return
 	}
@@ -45,14 +55,20 @@ func goObjectConstructed(obj *C.GObject) {
 	iface.Constructed(o)
 }
 
+//export goObjectDispose
+func goObjectDispose(obj *C.GObject) {
+	o := wrapObjectClean(unsafe.Pointer(obj))
+	subclass := FromObjectUnsafePrivate(unsafe.Pointer(obj))
+
+	subclass.(Disposer).Dispose(o)
+}
+
 //export goObjectFinalize
 func goObjectFinalize(obj *C.GObject, klass C.gpointer) {
 	o := wrapObjectClean(unsafe.Pointer(obj))
 	subclass := FromObjectUnsafePrivate(unsafe.Pointer(obj))
 
-	iface, ok := subclass.(interface{ Finalize(*Object) })
-
-	if ok {
+	if iface, ok := subclass.(Finalizer); ok {
 		// if the object wants to prevent premature cleanup it can do so in the Finalize function,
 		// since this will block the parent finalize call.
 		iface.Finalize(o)
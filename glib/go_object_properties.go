@@ -0,0 +1,342 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+extern void  setGObjectClassSetProperty  (void * klass);
+extern void  setGObjectClassGetProperty  (void * klass);
+*/
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// structPropertyIDOffset is added to the field index of struct properties to get their property id.
+// This keeps them apart from the ids used by InstallProperties.
+const structPropertyIDOffset = 1 << 16
+
+// StructPropertySetter can be implemented by objects using InstallStructProperties to set some of
+// the struct properties manually. If SetStructProperty returns false, the value is stored in the
+// tagged field as usual.
+type StructPropertySetter interface {
+	SetStructProperty(obj *Object, name string, value *Value) bool
+}
+
+// StructPropertyGetter can be implemented by objects using InstallStructProperties to retrieve some of
+// the struct properties manually. If GetStructProperty returns false, the value of the tagged field
+// is returned as usual.
+type StructPropertyGetter interface {
+	GetStructProperty(obj *Object, name string) (*Value, bool)
+}
+
+type structProperty struct {
+	name  string
+	index []int
+}
+
+// structProperties maps the param specs installed by InstallStructProperties to their struct fields.
+var structProperties = struct {
+	sync.RWMutex
+	m map[*C.GParamSpec]structProperty
+}{
+	m: make(map[*C.GParamSpec]structProperty),
+}
+
+// InstallStructProperties installs a property for every field of the struct goObject points to that has
+// a glib tag, and takes care of getting and setting them on instances of the class. It should be
+// called from ClassInit with the receiver of ClassInit.
+//
+// The glib tag holds the property name, optionally followed by a comma separated list of flags:
+// readable, writable, readwrite, construct, construct-only, lax-validation and deprecated.
+// Properties are readable and writable if neither is given. The nick, blurb, default, min and max
+// tags further describe the property:
+//
+//	type MyObject struct {
+//		Location string `glib:"location" nick:"Location" blurb:"The location to read from"`
+//		Rate     int    `glib:"rate,readwrite,construct" min:"1" max:"100" default:"10"`
+//	}
+//
+// Fields may be strings, bools, integers, floats or types registered with RegisterEnumType and
+// RegisterFlagsType. The "notify" signal is only emitted if setting a property changed its value.
+// Objects that need to handle some properties themselves can implement StructPropertySetter and
+// StructPropertyGetter. Properties installed with InstallProperties are still passed to SetProperty
+// and GetProperty.
+func (o *ObjectClass) InstallStructProperties(goObject interface{}) error {
+	t := reflect.TypeOf(goObject)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %s", t)
+	}
+
+	var params []*ParamSpec
+	var props []structProperty
+	for _, field := range reflect.VisibleFields(t.Elem()) {
+		tag, ok := field.Tag.Lookup("glib")
+		if !ok || tag == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("field %s must be exported to be used as a property", field.Name)
+		}
+		param, name, err := structFieldParamSpec(field, tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if param.paramSpec == nil {
+			return fmt.Errorf("field %s: invalid property %s", field.Name, name)
+		}
+		params = append(params, param)
+		props = append(props, structProperty{name: name, index: field.Index})
+	}
+
+	// the vfuncs must be in place before installing properties
+	C.setGObjectClassSetProperty(o.Unsafe())
+	C.setGObjectClassGetProperty(o.Unsafe())
+
+	structProperties.Lock()
+	defer structProperties.Unlock()
+
+	for idx, param := range params {
+		C.g_object_class_install_property(
+			o.Instance(),
+			C.guint(structPropertyIDOffset+idx),
+			param.paramSpec,
+		)
+		structProperties.m[param.paramSpec] = props[idx]
+	}
+
+	return nil
+}
+
+// structFieldParamSpec creates the param spec for a tagged struct field.
+func structFieldParamSpec(field reflect.StructField, tag string) (*ParamSpec, string, error) {
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		return nil, "", fmt.Errorf("missing property name")
+	}
+
+	var flags ParameterFlags
+	for _, flag := range parts[1:] {
+		switch strings.TrimSpace(flag) {
+		case "readable":
+			flags |= ParameterReadable
+		case "writable":
+			flags |= ParameterWritable
+		case "readwrite":
+			flags |= ParameterReadWrite
+		case "construct":
+			flags |= ParameterConstruct
+		case "construct-only":
+			flags |= ParameterConstructOnly
+		case "lax-validation":
+			flags |= ParameterLaxValidation
+		case "deprecated":
+			flags |= ParameterDeprecated
+		default:
+			return nil, "", fmt.Errorf("unknown property flag: %s", flag)
+		}
+	}
+	if !flags.Has(ParameterReadWrite) {
+		flags |= ParameterReadWrite
+	}
+	// notify is emitted by goObjectSetProperty, and only if the value changed
+	flags |= ParameterExplicitNotify
+
+	nick := field.Tag.Get("nick")
+	if nick == "" {
+		nick = name
+	}
+	blurb := field.Tag.Get("blurb")
+	def, hasDefault := field.Tag.Lookup("default")
+
+	if gtype, ok := goEnumType(field.Type); ok {
+		var defaultValue int64
+		if hasDefault {
+			var err error
+			if defaultValue, err = strconv.ParseInt(def, 0, 64); err != nil {
+				return nil, "", err
+			}
+		}
+		if gtype.IsA(TYPE_FLAGS) {
+			return NewFlagsParam(name, nick, blurb, gtype, uint(defaultValue), flags), name, nil
+		}
+		return NewEnumParam(name, nick, blurb, gtype, int(defaultValue), flags), name, nil
+	}
+
+	switch kind := field.Type.Kind(); kind {
+	case reflect.String:
+		var defaultValue *string
+		if hasDefault {
+			defaultValue = &def
+		}
+		return NewStringParam(name, nick, blurb, defaultValue, flags), name, nil
+
+	case reflect.Bool:
+		var defaultValue bool
+		if hasDefault {
+			var err error
+			if defaultValue, err = strconv.ParseBool(def); err != nil {
+				return nil, "", err
+			}
+		}
+		return NewBoolParam(name, nick, blurb, defaultValue, flags), name, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// go ints are stored in gint properties
+		size := 32
+		if kind != reflect.Int {
+			size = field.Type.Bits()
+		}
+		min, max, defaultValue, err := structTagRange(field, -1<<(size-1), 1<<(size-1)-1, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 0, size)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if size == 64 {
+			return NewInt64Param(name, nick, blurb, min, max, defaultValue, flags), name, nil
+		}
+		return NewIntParam(name, nick, blurb, int(min), int(max), int(defaultValue), flags), name, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size := 32
+		if kind != reflect.Uint {
+			size = field.Type.Bits()
+		}
+		min, max, defaultValue, err := structTagRange(field, 0, math.MaxUint64>>(64-size), func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 0, size)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if size == 64 {
+			return NewUint64Param(name, nick, blurb, min, max, defaultValue, flags), name, nil
+		}
+		return NewUintParam(name, nick, blurb, uint(min), uint(max), uint(defaultValue), flags), name, nil
+
+	case reflect.Float32:
+		min, max, defaultValue, err := structTagRange(field, -math.MaxFloat32, math.MaxFloat32, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 32)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return NewFloat32Param(name, nick, blurb, float32(min), float32(max), float32(defaultValue), flags), name, nil
+
+	case reflect.Float64:
+		min, max, defaultValue, err := structTagRange(field, -math.MaxFloat64, math.MaxFloat64, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return NewFloat64Param(name, nick, blurb, min, max, defaultValue, flags), name, nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported property type: %s", field.Type)
+}
+
+// structTagRange parses the min, max and default tags of a numeric field. min and max default to the
+// given values, the default value to zero clamped to the range.
+func structTagRange[T int64 | uint64 | float64](field reflect.StructField, min, max T, parse func(string) (T, error)) (T, T, T, error) {
+	values := [3]T{min, max, 0}
+	for i, key := range [3]string{"min", "max", "default"} {
+		s, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		v, err := parse(s)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		values[i] = v
+	}
+
+	min, max, defaultValue := values[0], values[1], values[2]
+	if min > max {
+		return 0, 0, 0, fmt.Errorf("min %v is greater than max %v", min, max)
+	}
+	if _, ok := field.Tag.Lookup("default"); !ok {
+		defaultValue = clamp(defaultValue, min, max)
+	} else if defaultValue < min || defaultValue > max {
+		return 0, 0, 0, fmt.Errorf("default %v is not between min %v and max %v", defaultValue, min, max)
+	}
+	return min, max, defaultValue, nil
+}
+
+func clamp[T int64 | uint64 | float64](v, min, max T) T {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// lookupStructProperty returns the struct property that was installed for the given param spec.
+func lookupStructProperty(param *C.GParamSpec) (structProperty, bool) {
+	structProperties.RLock()
+	defer structProperties.RUnlock()
+
+	prop, ok := structProperties.m[param]
+	return prop, ok
+}
+
+// setStructProperty sets the value on the struct field of subclass. It emits notify if the value changed.
+func setStructProperty(object *Object, subclass GoObjectSubclass, prop structProperty, param *C.GParamSpec, value *Value) {
+	if setter, ok := subclass.(StructPropertySetter); ok && setter.SetStructProperty(object, prop.name, value) {
+		return
+	}
+
+	goValue, err := value.GoValue()
+	if err != nil {
+		reportMarshalError(fmt.Errorf("cannot set property %s: %w", prop.name, err))
+		return
+	}
+
+	field := reflect.ValueOf(subclass).Elem().FieldByIndex(prop.index)
+	newValue := reflect.ValueOf(goValue).Convert(field.Type())
+
+	if field.Equal(newValue) {
+		return
+	}
+	field.Set(newValue)
+
+	object.NotifyByPspec(newParamSpec(param))
+}
+
+// getStructProperty returns a Value of the param spec's type holding the struct field of subclass.
+func getStructProperty(object *Object, subclass GoObjectSubclass, prop structProperty, param *C.GParamSpec) *Value {
+	if getter, ok := subclass.(StructPropertyGetter); ok {
+		if val, ok := getter.GetStructProperty(object, prop.name); ok {
+			return val
+		}
+	}
+
+	field := reflect.ValueOf(subclass).Elem().FieldByIndex(prop.index)
+
+	val, err := gValue(field.Interface())
+	if err != nil {
+		return nil
+	}
+
+	if actual, _, _ := val.Type(); actual != Type(param.value_type) {
+		// go integers of other sizes are stored in int, uint or int64 properties
+		transformed, err := ValueInit(Type(param.value_type))
+		if err != nil {
+			return nil
+		}
+		if !gobool(C.g_value_transform(val.native(), transformed.native())) {
+			return nil
+		}
+		return transformed
+	}
+	return val
+}
@@ -0,0 +1,259 @@
+package glib_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type structPropertiesObject struct {
+	Location string  `glib:"location" nick:"Location" blurb:"The location to read from" default:"here"`
+	Rate     int8    `glib:"rate,readwrite,construct" min:"1" max:"100" default:"10"`
+	Volume   float64 `glib:"volume" max:"1"`
+	Enabled  bool    `glib:"enabled" default:"true"`
+
+	ignored int
+}
+
+var structPropertiesClassErr error
+
+func (s *structPropertiesObject) New() glib.GoObjectSubclass { return &structPropertiesObject{} }
+
+func (s *structPropertiesObject) ClassInit(klass *glib.ObjectClass) {
+	structPropertiesClassErr = klass.InstallStructProperties(s)
+}
+
+func TestInstallStructProperties(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibStructPropertiesObject", &structPropertiesObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if structPropertiesClassErr != nil {
+		t.Fatal(structPropertiesClassErr)
+	}
+
+	goObj := glib.FromObjectUnsafePrivate(obj.Unsafe()).(*structPropertiesObject)
+
+	// construct properties are set to their defaults when the object is created
+	if goObj.Rate != 10 {
+		t.Fatalf("expected rate to be initialized to 10, got %d", goObj.Rate)
+	}
+
+	var notified int
+	if _, err := obj.Connect("notify::rate", func() { notified++ }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := obj.SetProperty("rate", 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := obj.SetProperty("rate", 42); err != nil {
+		t.Fatal(err)
+	}
+	if goObj.Rate != 42 {
+		t.Fatalf("expected rate 42, got %d", goObj.Rate)
+	}
+	if notified != 1 {
+		t.Fatalf("expected notify to be emitted once, got %d", notified)
+	}
+
+	goObj.Location = "there"
+	location, err := obj.GetProperty("location")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "there" {
+		t.Fatalf("expected location to be there, got %v", location)
+	}
+
+	rate, err := obj.GetProperty("rate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 42 {
+		t.Fatalf("expected rate 42, got %v", rate)
+	}
+
+	if err := obj.SetProperty("volume", 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if goObj.Volume != 0.5 {
+		t.Fatalf("expected volume 0.5, got %v", goObj.Volume)
+	}
+
+	if len(obj.Class().ListProperties()) != 4 {
+		t.Fatal("expected 4 properties to be installed")
+	}
+}
+
+type structPropertiesCustomObject struct {
+	Count  uint      `glib:"count,readwrite,construct" min:"1" max:"10"`
+	Offset int       `glib:"offset" max:"-1"`
+	Mode   testMode  `glib:"mode,readwrite,construct" default:"1"`
+	Flags  testFlags `glib:"flags"`
+	Name   string    `glib:"name"`
+
+	// name is stored upper case by SetStructProperty
+	name string
+}
+
+var structPropertiesCustomClassErr error
+
+func (s *structPropertiesCustomObject) New() glib.GoObjectSubclass {
+	return &structPropertiesCustomObject{}
+}
+
+func (s *structPropertiesCustomObject) ClassInit(klass *glib.ObjectClass) {
+	structPropertiesCustomClassErr = klass.InstallStructProperties(s)
+}
+
+func (s *structPropertiesCustomObject) SetStructProperty(obj *glib.Object, name string, value *glib.Value) bool {
+	if name != "name" {
+		return false
+	}
+	str, _ := value.GetString()
+	s.name = strings.ToUpper(str)
+	return true
+}
+
+func (s *structPropertiesCustomObject) GetStructProperty(obj *glib.Object, name string) (*glib.Value, bool) {
+	if name != "name" {
+		return nil, false
+	}
+	val, _ := glib.GValue("custom " + s.name)
+	return val, true
+}
+
+type structPropertiesInvalidObject struct {
+	Count uint `glib:"count" min:"1" default:"0"`
+}
+
+var structPropertiesInvalidClassErr error
+
+func (s *structPropertiesInvalidObject) New() glib.GoObjectSubclass {
+	return &structPropertiesInvalidObject{}
+}
+
+func (s *structPropertiesInvalidObject) ClassInit(klass *glib.ObjectClass) {
+	structPropertiesInvalidClassErr = klass.InstallStructProperties(s)
+}
+
+func TestInstallStructPropertiesCustom(t *testing.T) {
+	registerTestMode(t)
+	registerTestFlags(t)
+
+	typ := glib.RegisterGoType("GoGlibStructPropertiesCustomObject", &structPropertiesCustomObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if structPropertiesCustomClassErr != nil {
+		t.Fatal(structPropertiesCustomClassErr)
+	}
+
+	goObj := glib.FromObjectUnsafePrivate(obj.Unsafe()).(*structPropertiesCustomObject)
+
+	// without a default tag the default is the closest value to zero in the range
+	if goObj.Count != 1 {
+		t.Fatalf("expected count to be initialized to 1, got %d", goObj.Count)
+	}
+	if goObj.Mode != testModeB {
+		t.Fatalf("expected mode to be initialized to testModeB, got %v", goObj.Mode)
+	}
+
+	params := make(map[string]*glib.ParamSpec)
+	for _, param := range obj.Class().ListProperties() {
+		params[param.Name()] = param
+	}
+
+	if min, _ := params["count"].Minimum(); min != uint(1) {
+		t.Fatalf("expected count minimum 1, got %v", min)
+	}
+	if max, _ := params["count"].Maximum(); max != uint(10) {
+		t.Fatalf("expected count maximum 10, got %v", max)
+	}
+	if max, _ := params["offset"].Maximum(); max != -1 {
+		t.Fatalf("expected offset maximum -1, got %v", max)
+	}
+	def, err := params["offset"].DefaultValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset, _ := def.GoValue(); offset != -1 {
+		t.Fatalf("expected offset default -1, got %v", offset)
+	}
+
+	count, err := glib.GValue(uint(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !params["count"].Validate(count) {
+		t.Fatal("expected count 50 to be out of range")
+	}
+	if v, _ := count.GoValue(); v != uint(10) {
+		t.Fatalf("expected count to be clamped to 10, got %v", v)
+	}
+
+	if err := obj.SetProperty("count", uint(5)); err != nil {
+		t.Fatal(err)
+	}
+	if goObj.Count != 5 {
+		t.Fatalf("expected count 5, got %d", goObj.Count)
+	}
+
+	if err := obj.SetProperty("mode", testModeA); err != nil {
+		t.Fatal(err)
+	}
+	if goObj.Mode != testModeA {
+		t.Fatalf("expected mode testModeA, got %v", goObj.Mode)
+	}
+
+	if err := obj.SetProperty("flags", testFlagRead|testFlagWrite); err != nil {
+		t.Fatal(err)
+	}
+	flags, err := obj.GetProperty("flags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags != testFlagRead|testFlagWrite {
+		t.Fatalf("expected flags read|write, got %T %v", flags, flags)
+	}
+
+	if err := obj.SetProperty("name", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if goObj.Name != "" || goObj.name != "VALUE" {
+		t.Fatalf("expected name to be set by SetStructProperty, got %q and %q", goObj.Name, goObj.name)
+	}
+	name, err := obj.GetProperty("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "custom VALUE" {
+		t.Fatalf("expected name from GetStructProperty, got %v", name)
+	}
+
+	// properties not handled by the overrides still use the fields
+	offset, err := obj.GetProperty("offset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset != 0 {
+		t.Fatalf("expected offset 0, got %v", offset)
+	}
+}
+
+func TestInstallStructPropertiesInvalidDefault(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibStructPropertiesInvalidObject", &structPropertiesInvalidObject{}, glib.ExtendsObject)
+
+	if _, err := glib.NewObjectWithProperties(typ, nil); err != nil {
+		t.Fatal(err)
+	}
+	if structPropertiesInvalidClassErr == nil {
+		t.Fatal("expected an error for a default outside of the range")
+	}
+}
@@ -87,6 +87,11 @@ func (v *Object) native() *C.GObject {
 func (v *Object) goValue() (interface{}, error) {
 	objType := Type(C._g_type_from_instance(C.gpointer(v.native())))
 	f, err := gValueMarshalers.lookupType(objType)
+
+	// types registered from go usually have no marshaler, use the closest parent that has one
+	for parent := objType.Parent(); err != nil && parent != TYPE_INVALID; parent = parent.Parent() {
+		f, err = gValueMarshalers.lookupType(parent)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +287,9 @@ func (v *Object) SetPropertyValue(name string, value *Value) error {
 	if err != nil {
 		return err
 	}
+	if value, err = toAliasedType(value, propType); err != nil {
+		return err
+	}
 	valType, _, err := value.Type()
 	if err != nil {
 		return err
@@ -323,9 +331,10 @@ var ErrSignalNotFound = errors.New("signal not found")
 var ErrSignalWrongNumberOfArgs = errors.New("wrong number of arguments")
 
 // Emit is a wrapper around g_signal_emitv() and emits the signal
-// specified by the string s to an Object.  Arguments to callback
-// functions connected to this signal must be specified in args.  Emit()
-// returns an interface{} which contains the go equivalent of the C return value.
+// specified by the string s to an Object.  s may contain a detail in the
+// form "signal::detail".  Arguments to callback functions connected to this
+// signal must be specified in args.  Emit() returns an interface{} which
+// contains the go equivalent of the C return value.
 //
 // Make sure that the Types are known to go-glib. Special types need to be registered with
 // RegisterGValueMarshalers before calling Emit.
@@ -334,9 +343,10 @@ func (v *Object) Emit(s string, args ...interface{}) (interface{}, error) {
 	defer C.free(unsafe.Pointer(cstr))
 
 	t := v.TypeFromInstance()
-	id := C.g_signal_lookup((*C.gchar)(cstr), C.GType(t))
 
-	if id == 0 {
+	var id C.guint
+	var detail C.GQuark
+	if !gobool(C.g_signal_parse_name((*C.gchar)(cstr), C.GType(t), &id, &detail, C.TRUE)) {
 		return nil, ErrSignalNotFound
 	}
 
@@ -362,11 +372,16 @@ func (v *Object) Emit(s string, args ...interface{}) (interface{}, error) {
 	C._val_list_insert(instanceAndParams, C.int(0), instanceValue.native())
 	defer runtime.KeepAlive(instanceValue) // keep the value alive until the signal has been emitted
 
+	signalArgTypes := unsafe.Slice(q.param_types, q.n_params)
 	for i := range args {
 		valueArg, err := GValue(args[i])
 		if err != nil {
 			return nil, fmt.Errorf("error converting arg %d to GValue: %s", i, err.Error())
 		}
+		valueArg, err = toAliasedType(valueArg, Type(signalArgTypes[i]&^C.G_SIGNAL_TYPE_STATIC_SCOPE))
+		if err != nil {
+			return nil, fmt.Errorf("error converting arg %d to GValue: %s", i, err.Error())
+		}
 		C._val_list_insert(instanceAndParams, C.int(i+1), valueArg.native())
 		defer runtime.KeepAlive(valueArg) // keep the value alive until the signal has been emitted
 	}
@@ -376,7 +391,6 @@ func (v *Object) Emit(s string, args ...interface{}) (interface{}, error) {
 
 	// check the values types against the signals types
 	values := unsafe.Slice(instanceAndParams, len(args)+1)
-	signalArgTypes := unsafe.Slice(q.param_types, q.n_params)
 	for i := range len(args) {
 		v := ValueFromNative(unsafe.Pointer(&values[i+1]))
 
@@ -394,17 +408,94 @@ func (v *Object) Emit(s string, args ...interface{}) (interface{}, error) {
 		if err != nil {
 			return nil, errors.New("error creating Value for return value")
 		}
-		C.g_signal_emitv(instanceAndParams, id, C.GQuark(0), ret.native())
+		C.g_signal_emitv(instanceAndParams, id, detail, ret.native())
 
 		return ret.GoValue()
 	}
 
 	// signal has no return value
-	C.g_signal_emitv(instanceAndParams, id, C.GQuark(0), nil)
+	C.g_signal_emitv(instanceAndParams, id, detail, nil)
 
 	return nil, nil
 }
 
+// HandlerIsConnected is a wrapper around g_signal_handler_is_connected().
+func (v *Object) HandlerIsConnected(handle SignalHandle) bool {
+	return gobool(C.g_signal_handler_is_connected(C.gpointer(v.GObject), C.gulong(handle)))
+}
+
+// HasHandlerPending is a wrapper around g_signal_has_handler_pending(). It returns true if handlers are
+// connected to the signal specified by detailedSignal, which may contain a detail. This can be used
+// to skip constructing expensive arguments for emissions no one listens to.
+func (v *Object) HasHandlerPending(detailedSignal string, mayBeBlocked bool) bool {
+	cstr := C.CString(detailedSignal)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var id C.guint
+	var detail C.GQuark
+	if !gobool(C.g_signal_parse_name((*C.gchar)(cstr), C.GType(v.TypeFromInstance()), &id, &detail, C.FALSE)) {
+		return false
+	}
+	return gobool(C.g_signal_has_handler_pending(C.gpointer(v.GObject), id, detail, gbool(mayBeBlocked)))
+}
+
+// HandlerFind is a wrapper around g_signal_handler_find(). It returns the first handler matching
+// all criteria selected by mask, or 0 if none was found. closure, fn and data are compared to the
+// C closure, the C callback and its user data of the handlers. They only exist for handlers
+// connected from C, use HandlerFindFunc to find handlers connected from go by their function.
+func (v *Object) HandlerFind(mask SignalMatchType, signal *Signal, detail Quark, closure, fn, data unsafe.Pointer) SignalHandle {
+	return SignalHandle(C.g_signal_handler_find(
+		C.gpointer(v.GObject),
+		C.GSignalMatchType(mask),
+		signal.id(),
+		C.GQuark(detail),
+		(*C.GClosure)(closure),
+		C.gpointer(fn),
+		C.gpointer(data),
+	))
+}
+
+// HandlersDisconnectMatched disconnects all handlers matching the criteria selected by mask, see
+// HandlerFind, and returns the number of disconnected handlers. Unlike
+// g_signal_handlers_disconnect_matched(), any criteria may be used, so that handlers connected from go,
+// which have no C callback or data, can be matched by signal and detail as well.
+func (v *Object) HandlersDisconnectMatched(mask SignalMatchType, signal *Signal, detail Quark, closure, fn, data unsafe.Pointer) uint {
+	var n uint
+	for {
+		handle := v.HandlerFind(mask, signal, detail, closure, fn, data)
+		if handle == 0 {
+			return n
+		}
+		v.HandlerDisconnect(handle)
+		n++
+	}
+}
+
+// HandlerFindFunc returns the first handler connected from go, e.g. with Connect, whose function is f
+// and that matches the signal and detail selected by mask, or 0 if none was found. Funcs are compared
+// by their code pointer, so handlers created from the same function literal all match f.
+// SIGNAL_MATCH_CLOSURE, SIGNAL_MATCH_FUNC and SIGNAL_MATCH_DATA are ignored in mask.
+func (v *Object) HandlerFindFunc(mask SignalMatchType, signal *Signal, detail Quark, f interface{}) SignalHandle {
+	mask &^= SIGNAL_MATCH_CLOSURE | SIGNAL_MATCH_FUNC | SIGNAL_MATCH_DATA
+	return findClosure(f, func(closure *C.GClosure) SignalHandle {
+		return v.HandlerFind(mask|SIGNAL_MATCH_CLOSURE, signal, detail, unsafe.Pointer(closure), nil, nil)
+	})
+}
+
+// HandlersDisconnectFunc disconnects all handlers matching f and the criteria selected by mask, see
+// HandlerFindFunc, and returns the number of disconnected handlers.
+func (v *Object) HandlersDisconnectFunc(mask SignalMatchType, signal *Signal, detail Quark, f interface{}) uint {
+	var n uint
+	for {
+		handle := v.HandlerFindFunc(mask, signal, detail, f)
+		if handle == 0 {
+			return n
+		}
+		v.HandlerDisconnect(handle)
+		n++
+	}
+}
+
 // HandlerBlock is a wrapper around g_signal_handler_block().
 func (v *Object) HandlerBlock(handle SignalHandle) {
 	C.g_signal_handler_block(C.gpointer(v.GObject), C.gulong(handle))
@@ -50,6 +50,8 @@ func (o *ObjectClass) ListProperties() []*ParamSpec {
 	out := make([]*ParamSpec, 0)
 
 	for _, prop := range (*[(math.MaxInt32 - 1) / unsafe.Sizeof((*C.GParamSpec)(nil))]*C.GParamSpec)(unsafe.Pointer(props))[:size:size] {
+		// the param specs are owned by the class
+		C.g_param_spec_ref(prop)
 		ps := ToParamSpec(unsafe.Pointer(prop))
 		runtime.SetFinalizer(ps, (*ParamSpec).Unref)
 		out = append(out, ps)
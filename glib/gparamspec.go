@@ -14,10 +14,33 @@ GFlagsValue *   getParamSpecFlags     (GParamSpec * p, guint * size)
 	*size = i;
 	return vals;
 }
+
+GEnumValue *    getParamSpecEnums     (GParamSpec * p, guint * size)
+{
+	GParamSpecEnum * penum = G_PARAM_SPEC_ENUM (p);
+	*size = penum->enum_class->n_values;
+	return penum->enum_class->values;
+}
+
+// casts to the numeric param specs, returning NULL if p is of another type
+#define PARAM_SPEC_CAST(Name, NAME) \
+	static GParamSpec##Name * toParamSpec##Name (GParamSpec * p) { return G_IS_PARAM_SPEC_##NAME(p) ? G_PARAM_SPEC_##NAME(p) : NULL; }
+
+PARAM_SPEC_CAST(Char, CHAR)
+PARAM_SPEC_CAST(UChar, UCHAR)
+PARAM_SPEC_CAST(Int, INT)
+PARAM_SPEC_CAST(UInt, UINT)
+PARAM_SPEC_CAST(Long, LONG)
+PARAM_SPEC_CAST(ULong, ULONG)
+PARAM_SPEC_CAST(Int64, INT64)
+PARAM_SPEC_CAST(UInt64, UINT64)
+PARAM_SPEC_CAST(Float, FLOAT)
+PARAM_SPEC_CAST(Double, DOUBLE)
 */
 import "C"
 
 import (
+	"fmt"
 	"math"
 	"unsafe"
 )
@@ -65,6 +88,106 @@ func (p *ParamSpec) OwnerType() Type {
 // Unref the underlying paramater spec.
 func (p *ParamSpec) Unref() { C.g_param_spec_unref(p.paramSpec) }
 
+// DefaultValue returns a new Value holding the default value of the parameter.
+func (p *ParamSpec) DefaultValue() (*Value, error) {
+	val, err := ValueInit(p.ValueType())
+	if err != nil {
+		return nil, err
+	}
+	C.g_param_value_set_default(p.paramSpec, val.native())
+	return val, nil
+}
+
+// ValueIsDefault is a wrapper around g_param_value_defaults(). It returns true if v holds the default
+// value of the parameter.
+func (p *ParamSpec) ValueIsDefault(v *Value) bool {
+	return gobool(C.g_param_value_defaults(p.paramSpec, v.native()))
+}
+
+// Validate is a wrapper around g_param_value_validate(). It modifies v to conform to the parameter, for
+// example by clamping numbers to its range, and returns true if v was changed.
+func (p *ParamSpec) Validate(v *Value) bool {
+	return gobool(C.g_param_value_validate(p.paramSpec, v.native()))
+}
+
+// Convert is a wrapper around g_param_value_convert(). It transforms src to the type of the parameter
+// and validates it. If strict is true, values that had to be modified to conform to the parameter are
+// rejected, otherwise they are fixed up. Strings are parsed like in Value.TransformTo.
+func (p *ParamSpec) Convert(src *Value, strict bool) (*Value, error) {
+	srcType, _, err := src.Type()
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err := ValueInit(p.ValueType())
+	if err != nil {
+		return nil, err
+	}
+	if ValueTypeTransformable(srcType, p.ValueType()) {
+		if !gobool(C.g_param_value_convert(p.paramSpec, src.native(), dest.native(), gbool(strict))) {
+			return nil, fmt.Errorf("cannot convert %s to a valid value for %s", srcType.Name(), p.Name())
+		}
+		return dest, nil
+	}
+
+	dest, err = src.TransformTo(p.ValueType())
+	if err != nil {
+		return nil, err
+	}
+	if p.Validate(dest) && strict {
+		return nil, fmt.Errorf("value is not valid for %s", p.Name())
+	}
+	return dest, nil
+}
+
+// Minimum returns the minimum of a numeric parameter as the go type of its values, see ValueGet.
+// It returns false if the parameter is not numeric.
+func (p *ParamSpec) Minimum() (interface{}, bool) {
+	min, _, ok := p.numericRange()
+	return min, ok
+}
+
+// Maximum returns the maximum of a numeric parameter as the go type of its values, see ValueGet.
+// It returns false if the parameter is not numeric.
+func (p *ParamSpec) Maximum() (interface{}, bool) {
+	_, max, ok := p.numericRange()
+	return max, ok
+}
+
+func (p *ParamSpec) numericRange() (min, max interface{}, ok bool) {
+	if s := C.toParamSpecChar(p.paramSpec); s != nil {
+		return int8(s.minimum), int8(s.maximum), true
+	}
+	if s := C.toParamSpecUChar(p.paramSpec); s != nil {
+		return uint8(s.minimum), uint8(s.maximum), true
+	}
+	if s := C.toParamSpecInt(p.paramSpec); s != nil {
+		return int(s.minimum), int(s.maximum), true
+	}
+	if s := C.toParamSpecUInt(p.paramSpec); s != nil {
+		return uint(s.minimum), uint(s.maximum), true
+	}
+	if s := C.toParamSpecLong(p.paramSpec); s != nil {
+		return int(s.minimum), int(s.maximum), true
+	}
+	if s := C.toParamSpecULong(p.paramSpec); s != nil {
+		return uint(s.minimum), uint(s.maximum), true
+	}
+	if s := C.toParamSpecInt64(p.paramSpec); s != nil {
+		return int64(s.minimum), int64(s.maximum), true
+	}
+	if s := C.toParamSpecUInt64(p.paramSpec); s != nil {
+		return uint64(s.minimum), uint64(s.maximum), true
+	}
+	if s := C.toParamSpecFloat(p.paramSpec); s != nil {
+		return float32(s.minimum), float32(s.maximum), true
+	}
+	if s := C.toParamSpecDouble(p.paramSpec); s != nil {
+		return float64(s.minimum), float64(s.maximum), true
+	}
+	return nil, nil, false
+}
+
 // FlagsValue is a go representation of GFlagsValue
 type FlagsValue struct {
 	Value                int
@@ -88,6 +211,23 @@ func (p *ParamSpec) GetFlagValues() []*FlagsValue {
 	return out
 }
 
+// GetEnumValues returns the possible values for this enum parameter.
+func (p *ParamSpec) GetEnumValues() []*EnumValue {
+	var gSize C.guint
+	gEnums := C.getParamSpecEnums(p.paramSpec, &gSize)
+	size := int(gSize)
+	out := make([]*EnumValue, size)
+
+	for idx, enum := range unsafe.Slice(gEnums, size) {
+		out[idx] = &EnumValue{
+			Value:     int(enum.value),
+			ValueNick: C.GoString(enum.value_nick),
+			ValueName: C.GoString(enum.value_name),
+		}
+	}
+	return out
+}
+
 // ParameterFlags is a go cast of GParamFlags.
 type ParameterFlags int
 
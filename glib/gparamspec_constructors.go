@@ -192,3 +192,220 @@ func NewBoxedParam(name, nick, blurb string, boxedType Type, flags ParameterFlag
 	)
 	return &ParamSpec{paramSpec: paramSpec}
 }
+
+// NewEnumParam creates a new ParamSpec that will hold a value of the given enum type.
+func NewEnumParam(name, nick, blurb string, enumType Type, defaultValue int, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_enum(
+		cname,
+		cnick,
+		cblurb,
+		C.GType(enumType),
+		C.gint(defaultValue),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewFlagsParam creates a new ParamSpec that will hold a value of the given flags type.
+func NewFlagsParam(name, nick, blurb string, flagsType Type, defaultValue uint, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_flags(
+		cname,
+		cnick,
+		cblurb,
+		C.GType(flagsType),
+		C.guint(defaultValue),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewCharParam creates a new ParamSpec that will hold a signed 8-bit integer value.
+func NewCharParam(name, nick, blurb string, min, max, defaultValue int8, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_char(
+		cname,
+		cnick,
+		cblurb,
+		C.gint8(min),
+		C.gint8(max),
+		C.gint8(defaultValue),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewUcharParam creates a new ParamSpec that will hold an unsigned 8-bit integer value.
+func NewUcharParam(name, nick, blurb string, min, max, defaultValue uint8, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_uchar(
+		cname,
+		cnick,
+		cblurb,
+		C.guint8(min),
+		C.guint8(max),
+		C.guint8(defaultValue),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewUnicharParam creates a new ParamSpec that will hold a unicode character. The value is stored
+// as a uint, invalid characters are replaced by 0 when validating.
+func NewUnicharParam(name, nick, blurb string, defaultValue rune, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_unichar(
+		cname,
+		cnick,
+		cblurb,
+		C.gunichar(defaultValue),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewObjectParam creates a new ParamSpec that will hold an object of the given type or one of
+// its subtypes.
+func NewObjectParam(name, nick, blurb string, objectType Type, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_object(
+		cname,
+		cnick,
+		cblurb,
+		C.GType(objectType),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewVariantParam creates a new ParamSpec that will hold a variant of the given type. defaultValue
+// may be nil, otherwise it must be of variantType.
+func NewVariantParam(name, nick, blurb string, variantType *VariantType, defaultValue *Variant, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_variant(
+		cname,
+		cnick,
+		cblurb,
+		variantType.native(),
+		defaultValue.native(), // sunk or referenced by the param spec
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewGTypeParam creates a new ParamSpec that will hold a Type deriving from isAType. Use TYPE_NONE
+// to allow any type.
+func NewGTypeParam(name, nick, blurb string, isAType Type, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_gtype(
+		cname,
+		cnick,
+		cblurb,
+		C.GType(isAType),
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewPointerParam creates a new ParamSpec that will hold an untyped pointer.
+func NewPointerParam(name, nick, blurb string, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	paramSpec := C.g_param_spec_pointer(
+		cname,
+		cnick,
+		cblurb,
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewValueArrayParam creates a new ParamSpec that will hold a GValueArray. If elementSpec is not nil,
+// the elements of the array are validated against it. The new ParamSpec takes ownership of elementSpec.
+func NewValueArrayParam(name, nick, blurb string, elementSpec *ParamSpec, flags ParameterFlags) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+	cnick := (*C.gchar)(C.CString(nick))
+	defer C.free(unsafe.Pointer(cnick))
+	cblurb := (*C.gchar)(C.CString(blurb))
+	defer C.free(unsafe.Pointer(cblurb))
+
+	var celement *C.GParamSpec
+	if elementSpec != nil {
+		celement = elementSpec.paramSpec
+	}
+
+	paramSpec := C.g_param_spec_value_array(
+		cname,
+		cnick,
+		cblurb,
+		celement,
+		C.GParamFlags(flags),
+	)
+	return &ParamSpec{paramSpec: paramSpec}
+}
+
+// NewOverrideParam creates a new ParamSpec that redirects to overridden. Install it on a class to
+// implement a property of an interface or to override a property of the parent class under the
+// same name.
+func NewOverrideParam(name string, overridden *ParamSpec) *ParamSpec {
+	cname := (*C.gchar)(C.CString(name))
+	defer C.free(unsafe.Pointer(cname))
+
+	paramSpec := C.g_param_spec_override(cname, overridden.paramSpec)
+	return &ParamSpec{paramSpec: paramSpec}
+}
@@ -0,0 +1,61 @@
+package glib_test
+
+import (
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+func TestParamSpecConstructors(t *testing.T) {
+	tests := []struct {
+		param     *glib.ParamSpec
+		valueType glib.Type
+		def       interface{}
+	}{
+		{glib.NewCharParam("char", "", "", -10, 10, -3, glib.ParameterReadWrite), glib.TYPE_CHAR, int8(-3)},
+		{glib.NewUcharParam("uchar", "", "", 1, 10, 3, glib.ParameterReadWrite), glib.TYPE_UCHAR, uint8(3)},
+		{glib.NewUnicharParam("unichar", "", "", 'ä', glib.ParameterReadWrite), glib.TYPE_UINT, uint('ä')},
+		{glib.NewObjectParam("object", "", "", glib.TYPE_OBJECT, glib.ParameterReadWrite), glib.TYPE_OBJECT, (*glib.Object)(nil)},
+		{glib.NewVariantParam("variant", "", "", glib.VARIANT_TYPE_STRING, glib.VariantFromString("foo"), glib.ParameterReadWrite), glib.TYPE_VARIANT, nil},
+		{glib.NewGTypeParam("gtype", "", "", glib.TYPE_OBJECT, glib.ParameterReadWrite), glib.TYPE_GTYPE, glib.TYPE_OBJECT},
+		{glib.NewPointerParam("pointer", "", "", glib.ParameterReadWrite), glib.TYPE_POINTER, nil},
+		{glib.NewValueArrayParam("array", "", "", glib.NewIntParam("element", "", "", 0, 1, 0, glib.ParameterReadWrite), glib.ParameterReadWrite), glib.TYPE_VALUE_ARRAY, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.param.Name(), func(t *testing.T) {
+			if tt.param.ValueType() != tt.valueType {
+				t.Fatalf("expected value type %s, got %s", tt.valueType.Name(), tt.param.ValueType().Name())
+			}
+
+			def, err := tt.param.DefaultValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.def == nil {
+				return
+			}
+			if got, err := def.GoValue(); err != nil || got != tt.def {
+				t.Fatalf("expected default %v, got %v (%v)", tt.def, got, err)
+			}
+		})
+	}
+
+	variantParam := tests[4].param
+	def, err := variantParam.DefaultValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := glib.ValueGet[*glib.Variant](def); err != nil || v.GetString() != "foo" {
+		t.Fatalf("expected the variant \"foo\", got %v (%v)", v, err)
+	}
+
+	if min, ok := tests[0].param.Minimum(); !ok || min != int8(-10) {
+		t.Fatalf("expected minimum -10, got %v", min)
+	}
+
+	override := glib.NewOverrideParam("gtype", tests[5].param)
+	if override.ValueType() != glib.TYPE_GTYPE || override.Name() != "gtype" {
+		t.Fatalf("unexpected override %s of type %s", override.Name(), override.ValueType().Name())
+	}
+}
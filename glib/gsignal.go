@@ -0,0 +1,294 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+extern gboolean goSignalAccumulator   (GSignalInvocationHint * ihint, GValue * return_accu, GValue * handler_return, gpointer data);
+extern gboolean goSignalEmissionHook  (GSignalInvocationHint * ihint, guint n_param_values, GValue * param_values, gpointer data);
+extern void     goSignalEmissionHookDestroy (gpointer data);
+
+static gboolean cgoSignalAccumulator (GSignalInvocationHint * ihint, GValue * return_accu, const GValue * handler_return, gpointer data)
+{
+	return goSignalAccumulator(ihint, return_accu, (GValue *) handler_return, data);
+}
+
+static gboolean cgoSignalEmissionHook (GSignalInvocationHint * ihint, guint n_param_values, const GValue * param_values, gpointer data)
+{
+	return goSignalEmissionHook(ihint, n_param_values, (GValue *) param_values, data);
+}
+
+static gulong _g_signal_add_emission_hook (guint signal_id, GQuark detail, gpointer data)
+{
+	return g_signal_add_emission_hook(signal_id, detail, cgoSignalEmissionHook, data, goSignalEmissionHookDestroy);
+}
+
+static guint _g_signal_newv (const gchar * name, GType itype, GSignalFlags flags, GClosure * class_closure,
+                             gpointer accu_data, GType return_type, guint n_params, GType * param_types)
+{
+	return g_signal_newv(name, itype, flags, class_closure,
+	                     accu_data ? cgoSignalAccumulator : NULL, accu_data,
+	                     NULL, return_type, n_params, param_types);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+// SignalFlags is a go cast of GSignalFlags.
+type SignalFlags int
+
+// Type casting of GSignalFlags
+const (
+	SIGNAL_RUN_FIRST    SignalFlags = C.G_SIGNAL_RUN_FIRST    // invoke the object method handler in the first emission stage
+	SIGNAL_RUN_LAST     SignalFlags = C.G_SIGNAL_RUN_LAST     // invoke the object method handler in the third emission stage
+	SIGNAL_RUN_CLEANUP  SignalFlags = C.G_SIGNAL_RUN_CLEANUP  // invoke the object method handler in the last emission stage
+	SIGNAL_NO_RECURSE   SignalFlags = C.G_SIGNAL_NO_RECURSE   // signals being emitted for an object while currently being in emission for this very object will not be emitted recursively, but instead cause the first emission to be restarted
+	SIGNAL_DETAILED     SignalFlags = C.G_SIGNAL_DETAILED     // this signal supports "::detail" appendices to the signal name upon handler connections and emissions
+	SIGNAL_ACTION       SignalFlags = C.G_SIGNAL_ACTION       // action signals are signals that may freely be emitted on alive objects from user code
+	SIGNAL_NO_HOOKS     SignalFlags = C.G_SIGNAL_NO_HOOKS     // no emissions hooks are supported for this signal
+	SIGNAL_MUST_COLLECT SignalFlags = C.G_SIGNAL_MUST_COLLECT // varargs signal emission will always collect the arguments, even if there are no signal handlers connected
+	SIGNAL_DEPRECATED   SignalFlags = C.G_SIGNAL_DEPRECATED   // the signal is deprecated and will be removed in a future version
+)
+
+// Has returns true if these flags contain the provided ones.
+func (f SignalFlags) Has(b SignalFlags) bool { return f&b != 0 }
+
+// SignalMatchType is a go cast of GSignalMatchType. It selects the criteria used to match handlers.
+type SignalMatchType int
+
+// Type casting of GSignalMatchType
+const (
+	SIGNAL_MATCH_ID        SignalMatchType = C.G_SIGNAL_MATCH_ID        // the signal id must be equal
+	SIGNAL_MATCH_DETAIL    SignalMatchType = C.G_SIGNAL_MATCH_DETAIL    // the signal detail must be equal
+	SIGNAL_MATCH_CLOSURE   SignalMatchType = C.G_SIGNAL_MATCH_CLOSURE   // the closure must be the same
+	SIGNAL_MATCH_FUNC      SignalMatchType = C.G_SIGNAL_MATCH_FUNC      // the C closure callback must be the same
+	SIGNAL_MATCH_DATA      SignalMatchType = C.G_SIGNAL_MATCH_DATA      // the closure data must be the same
+	SIGNAL_MATCH_UNBLOCKED SignalMatchType = C.G_SIGNAL_MATCH_UNBLOCKED // only unblocked signals may be matched
+)
+
+// SignalInvocationHint is a go representation of a GSignalInvocationHint. It is passed
+// to accumulators and describes the emission that is currently running.
+type SignalInvocationHint struct {
+	// SignalID is the id of the signal being emitted.
+	SignalID uint
+	// Detail is the detail passed on for this emission.
+	Detail Quark
+	// RunType is the stage the signal emission is currently in.
+	RunType SignalFlags
+}
+
+func newSignalInvocationHint(ihint *C.GSignalInvocationHint) *SignalInvocationHint {
+	if ihint == nil {
+		return nil
+	}
+	return &SignalInvocationHint{
+		SignalID: uint(ihint.signal_id),
+		Detail:   Quark(ihint.detail),
+		RunType:  SignalFlags(ihint.run_type),
+	}
+}
+
+// SignalAccumulator is a go representation of a GSignalAccumulator. It is called after each
+// handler of a signal with a return value has run. returnAccu holds the value that will be
+// returned from the emission and should be updated from handlerReturn. Returning false stops
+// the emission, so that no further handlers are run.
+type SignalAccumulator func(hint *SignalInvocationHint, returnAccu *Value, handlerReturn *Value) bool
+
+// NewSignal is a wrapper around g_signal_newv(). It creates a new signal on the type of this class
+// and should be called from the ClassInit of a GoObjectSubclass.
+//
+// flags should contain one of SIGNAL_RUN_FIRST, SIGNAL_RUN_LAST or SIGNAL_RUN_CLEANUP to
+// define when classHandler is invoked. returnType may be TYPE_NONE if the signal has no return
+// value, in which case accumulator must be nil.
+//
+// classHandler is optional and must be a function with a signature matching the signal, the
+// first argument being the emitting instance. It is invoked through the same closure machinery
+// as handlers passed to Object.Connect. accumulator is optional as well and defaults to using
+// the return value of the last handler that ran.
+func (o *ObjectClass) NewSignal(name string, flags SignalFlags, returnType Type, paramTypes []Type, classHandler interface{}, accumulator SignalAccumulator) (*Signal, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	if !gobool(C.g_signal_is_valid_name((*C.gchar)(cname))) {
+		return nil, fmt.Errorf("invalid signal name: %s", name)
+	}
+
+	if returnType == TYPE_INVALID {
+		returnType = TYPE_NONE
+	}
+	if returnType == TYPE_NONE && accumulator != nil {
+		return nil, errors.New("accumulator given for signal without return value")
+	}
+
+	var classClosure *C.GClosure
+	if classHandler != nil {
+		closure, err := ClosureNew(classHandler)
+		if err != nil {
+			return nil, err
+		}
+		// the signal takes its own reference to the class closure
+		defer C.g_closure_unref(closure)
+		classClosure = closure
+	}
+
+	var accuData C.gpointer
+	if accumulator != nil {
+		// signals can never be removed from a class, so the accumulator is never released
+		accuData = C.gpointer(gopointer.Save(accumulator))
+	}
+
+	var cParamTypes *C.GType
+	if len(paramTypes) > 0 {
+		types := make([]C.GType, len(paramTypes))
+		for i, t := range paramTypes {
+			types[i] = C.GType(t)
+		}
+		cParamTypes = unsafe.SliceData(types)
+	}
+
+	signalID := C._g_signal_newv(
+		(*C.gchar)(cname),
+		C._g_type_from_class(C.gpointer(o.Unsafe())),
+		C.GSignalFlags(flags),
+		classClosure,
+		accuData,
+		C.GType(returnType),
+		C.guint(len(paramTypes)),
+		cParamTypes,
+	)
+
+	if signalID == 0 {
+		if accuData != nil {
+			gopointer.Unref(unsafe.Pointer(accuData))
+		}
+		return nil, fmt.Errorf("could not create signal: %s", name)
+	}
+
+	return &Signal{
+		name:     name,
+		signalId: signalID,
+	}, nil
+}
+
+// SignalEmissionHook is a go representation of a GSignalEmissionHook. It is called for every emission
+// of a signal, on any instance, with the instance followed by the parameters of the emission.
+// Returning false removes the hook.
+type SignalEmissionHook func(hint *SignalInvocationHint, params []*Value) bool
+
+// EmissionHookID identifies an emission hook added with Signal.AddEmissionHook.
+type EmissionHookID uint64
+
+// AddEmissionHook is a wrapper around g_signal_add_emission_hook(). hook is called for every emission
+// of the signal with the given detail, or with any detail if detail is 0. Signals created with
+// SIGNAL_NO_HOOKS do not support emission hooks.
+func (s *Signal) AddEmissionHook(detail Quark, hook SignalEmissionHook) (EmissionHookID, error) {
+	var q C.GSignalQuery
+	C.g_signal_query(s.signalId, &q)
+	if q.signal_id == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSignalNotFound, s.name)
+	}
+	if SignalFlags(q.signal_flags).Has(SIGNAL_NO_HOOKS) {
+		return 0, fmt.Errorf("signal %s does not support emission hooks", s.name)
+	}
+
+	// the pointer is released by goSignalEmissionHookDestroy when the hook is removed
+	data := gopointer.Save(hook)
+	id := C._g_signal_add_emission_hook(s.signalId, C.GQuark(detail), C.gpointer(data))
+	return EmissionHookID(id), nil
+}
+
+// RemoveEmissionHook is a wrapper around g_signal_remove_emission_hook().
+func (s *Signal) RemoveEmissionHook(id EmissionHookID) {
+	C.g_signal_remove_emission_hook(s.signalId, C.gulong(id))
+}
+
+// SignalQuery is a go representation of a GSignalQuery. It describes a signal.
+type SignalQuery struct {
+	// ID is the id of the signal.
+	ID uint
+	// Name is the name of the signal.
+	Name string
+	// OwnerType is the type the signal was created on.
+	OwnerType Type
+	// Flags are the flags the signal was created with.
+	Flags SignalFlags
+	// ReturnType is the type of the return value, or TYPE_NONE.
+	ReturnType Type
+	// ParamTypes are the types of the parameters passed after the instance.
+	ParamTypes []Type
+}
+
+func newSignalQuery(id C.guint) *SignalQuery {
+	var q C.GSignalQuery
+	C.g_signal_query(id, &q)
+	if q.signal_id == 0 {
+		return nil
+	}
+
+	paramTypes := make([]Type, 0, int(q.n_params))
+	for _, t := range unsafe.Slice(q.param_types, q.n_params) {
+		paramTypes = append(paramTypes, Type(t&^C.G_SIGNAL_TYPE_STATIC_SCOPE))
+	}
+
+	return &SignalQuery{
+		ID:         uint(q.signal_id),
+		Name:       C.GoString((*C.char)(q.signal_name)),
+		OwnerType:  Type(q.itype),
+		Flags:      SignalFlags(q.signal_flags),
+		ReturnType: Type(q.return_type &^ C.G_SIGNAL_TYPE_STATIC_SCOPE),
+		ParamTypes: paramTypes,
+	}
+}
+
+// SignalLookup is a wrapper around g_signal_lookup(). It returns the existing signal with the given
+// name on t or one of its ancestors or interfaces. The class of t is initialized if needed, so that
+// signals created in its class init are found.
+func SignalLookup(name string, t Type) (*Signal, error) {
+	defer refTypeClass(t)()
+
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+
+	id := C.g_signal_lookup((*C.gchar)(cstr), C.GType(t))
+	if id == 0 {
+		return nil, fmt.Errorf("%w: %s on %s", ErrSignalNotFound, name, t.Name())
+	}
+
+	return &Signal{
+		name:     name,
+		signalId: id,
+	}, nil
+}
+
+// Query is a wrapper around g_signal_query(). It returns nil if the signal does not exist.
+func (s *Signal) Query() *SignalQuery {
+	return newSignalQuery(s.signalId)
+}
+
+// Signals is a wrapper around g_signal_list_ids(). It describes the signals created on the type
+// itself, the signals of its ancestors and interfaces are not included. Types that are neither
+// instantiatable nor interfaces have no signals.
+func (t Type) Signals() []*SignalQuery {
+	if !t.isClassed() && Type(C.g_type_fundamental(C.GType(t))) != TYPE_INTERFACE {
+		return nil
+	}
+	defer refTypeClass(t)()
+
+	var n C.guint
+	ids := C.g_signal_list_ids(C.GType(t), &n)
+	defer C.g_free(C.gpointer(ids))
+
+	signals := make([]*SignalQuery, 0, int(n))
+	for _, id := range unsafe.Slice(ids, n) {
+		if q := newSignalQuery(id); q != nil {
+			signals = append(signals, q)
+		}
+	}
+	return signals
+}
@@ -0,0 +1,49 @@
+package glib
+
+/*
+#include "glib.go.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+//export goSignalAccumulator
+func goSignalAccumulator(ihint *C.GSignalInvocationHint, returnAccu *C.GValue, handlerReturn *C.GValue, data C.gpointer) (ret C.gboolean) {
+	// a panicking accumulator stops the emission
+	ret = C.FALSE
+	defer recoverMarshalPanic()
+
+	accumulator := gopointer.Restore(unsafe.Pointer(data)).(SignalAccumulator)
+
+	return gbool(accumulator(
+		newSignalInvocationHint(ihint),
+		ValueFromNative(unsafe.Pointer(returnAccu)),
+		ValueFromNative(unsafe.Pointer(handlerReturn)),
+	))
+}
+
+//export goSignalEmissionHook
+func goSignalEmissionHook(ihint *C.GSignalInvocationHint, nParams C.guint, params *C.GValue, data C.gpointer) (ret C.gboolean) {
+	// a panicking hook is kept
+	ret = C.TRUE
+	defer recoverMarshalPanic()
+
+	hook := gopointer.Restore(unsafe.Pointer(data)).(SignalEmissionHook)
+
+	gValues := gValueSlice(params, int(nParams))
+	values := make([]*Value, len(gValues))
+	for i := range gValues {
+		values[i] = ValueFromNative(unsafe.Pointer(&gValues[i]))
+	}
+
+	return gbool(hook(newSignalInvocationHint(ihint), values))
+}
+
+//export goSignalEmissionHookDestroy
+func goSignalEmissionHookDestroy(data C.gpointer) {
+	gopointer.Unref(unsafe.Pointer(data))
+}
@@ -0,0 +1,317 @@
+package glib_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type signalTestObject struct{}
+
+var (
+	computeSignal      *glib.Signal
+	signalTestClassErr error
+)
+
+func (s *signalTestObject) New() glib.GoObjectSubclass { return &signalTestObject{} }
+
+func (s *signalTestObject) ClassInit(klass *glib.ObjectClass) {
+	computeSignal, signalTestClassErr = klass.NewSignal(
+		"compute",
+		glib.SIGNAL_RUN_LAST|glib.SIGNAL_ACTION|glib.SIGNAL_DETAILED,
+		glib.TYPE_INT,
+		[]glib.Type{glib.TYPE_INT},
+		func(obj *glib.Object, in int) int { return in * 2 },
+		func(hint *glib.SignalInvocationHint, returnAccu *glib.Value, handlerReturn *glib.Value) bool {
+			acc, _ := returnAccu.GoValue()
+			ret, _ := handlerReturn.GoValue()
+			if ret.(int) < 0 {
+				panic("negative result")
+			}
+			returnAccu.SetInt(acc.(int) + ret.(int))
+			return true
+		},
+	)
+}
+
+func TestObjectClassNewSignal(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signalTestClassErr != nil {
+		t.Fatal(signalTestClassErr)
+	}
+
+	// the class handler runs last and doubles the input, the connected handler adds one
+	_, err = obj.Connect("compute", func(obj *glib.Object, in int) int { return in + 1 })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ret, err := obj.Emit("compute", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.(int) != 31 {
+		t.Fatalf("expected 31, got %v", ret)
+	}
+}
+
+func TestConnectTyped(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := glib.ConnectTyped(obj, "compute", func(obj *glib.Object, in int) int { return in }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := glib.ConnectTyped(obj, "notify::foo", func(obj *glib.Object, pspec *glib.ParamSpec) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[string]interface{}{
+		"wrong parameter type": func(obj *glib.Object, in string) int { return 0 },
+		"wrong return type":    func(obj *glib.Object, in int) string { return "" },
+		"missing return value": func(obj *glib.Object, in int) {},
+		"too many parameters":  func(obj *glib.Object, in int, extra int) int { return 0 },
+	}
+	for name, f := range invalid {
+		if _, err := glib.ConnectTyped(obj, "compute", f); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := glib.ConnectTyped(obj, "does-not-exist", func() {}); !errors.Is(err, glib.ErrSignalNotFound) {
+		t.Fatalf("expected ErrSignalNotFound, got %v", err)
+	}
+}
+
+func TestMarshalErrorHandler(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported error
+	glib.SetMarshalErrorHandler(func(err error) { reported = err })
+	defer glib.SetMarshalErrorHandler(nil)
+
+	// the untyped Connect only notices the wrong signature when the signal is emitted
+	if _, err := obj.Connect("compute", func(obj *glib.Object, in int, extra int, more int) int { return 0 }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obj.Emit("compute", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !errors.Is(reported, glib.ErrTooManyClosureArgs) {
+		t.Fatalf("expected ErrTooManyClosureArgs to be reported, got %v", reported)
+	}
+}
+
+func TestCallbackPanic(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []error
+	glib.SetMarshalErrorHandler(func(err error) { reported = append(reported, err) })
+	defer glib.SetMarshalErrorHandler(nil)
+
+	if _, err := obj.Connect("compute", func(obj *glib.Object, in int) int { panic("handler failed") }); err != nil {
+		t.Fatal(err)
+	}
+	// the class handler still runs after the panicking handler
+	ret, err := obj.Emit("compute", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret != 4 {
+		t.Fatalf("expected 4, got %v", ret)
+	}
+
+	if _, err := glib.IdleAdd(func() bool { panic(errors.New("idle failed")) }); err != nil {
+		t.Fatal(err)
+	}
+	glib.MainContextDefault().Iteration(false)
+
+	// a panicking emission hook is kept
+	hook, err := computeSignal.AddEmissionHook(0, func(hint *glib.SignalInvocationHint, params []*glib.Value) bool {
+		panic("hook failed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer computeSignal.RemoveEmissionHook(hook)
+	for i := 0; i < 2; i++ {
+		if _, err := obj.Emit("compute", 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(reported) != 6 {
+		t.Fatalf("expected 6 reported panics, got %v", reported)
+	}
+	var panicErr *glib.PanicError
+	if !errors.As(reported[0], &panicErr) || panicErr.Value != "handler failed" || len(panicErr.Stack) == 0 {
+		t.Fatalf("unexpected error for the signal handler: %v", reported[0])
+	}
+	if !errors.As(reported[1], &panicErr) || panicErr.Unwrap() == nil || panicErr.Unwrap().Error() != "idle failed" {
+		t.Fatalf("unexpected error for the idle source: %v", reported[1])
+	}
+	// the hook runs before the handler in each emission
+	for _, i := range []int{2, 4} {
+		if !errors.As(reported[i], &panicErr) || panicErr.Value != "hook failed" {
+			t.Fatalf("unexpected error for the emission hook: %v", reported[i])
+		}
+	}
+}
+
+func TestAccumulatorPanic(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []error
+	glib.SetMarshalErrorHandler(func(err error) { reported = append(reported, err) })
+	defer glib.SetMarshalErrorHandler(nil)
+
+	// the accumulator panics for the negative result of the class handler
+	if _, err := obj.Emit("compute", -1); err != nil {
+		t.Fatal(err)
+	}
+
+	var panicErr *glib.PanicError
+	if len(reported) != 1 || !errors.As(reported[0], &panicErr) || panicErr.Value != "negative result" {
+		t.Fatalf("unexpected errors: %v", reported)
+	}
+}
+
+func TestSignalHandlerQueries(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var emissions []string
+	hook, err := computeSignal.AddEmissionHook(0, func(hint *glib.SignalInvocationHint, params []*glib.Value) bool {
+		in, _ := params[1].GoValue()
+		emissions = append(emissions, fmt.Sprintf("%s:%v", hint.Detail, in))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer computeSignal.RemoveEmissionHook(hook)
+
+	if obj.HasHandlerPending("compute", false) {
+		t.Fatal("expected no pending handlers")
+	}
+
+	var called int
+	handle, err := obj.Connect("compute::a", func(obj *glib.Object, in int) int { called++; return in })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !obj.HasHandlerPending("compute::a", false) || obj.HasHandlerPending("compute::b", false) {
+		t.Fatal("expected a pending handler for detail a only")
+	}
+
+	if _, err := obj.Emit("compute::b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obj.Emit("compute::a", 2); err != nil {
+		t.Fatal(err)
+	}
+	if called != 1 {
+		t.Fatalf("expected the handler to be called once, got %d", called)
+	}
+	if len(emissions) != 2 || emissions[0] != "b:1" || emissions[1] != "a:2" {
+		t.Fatalf("unexpected emissions: %v", emissions)
+	}
+
+	mask := glib.SIGNAL_MATCH_ID | glib.SIGNAL_MATCH_DETAIL
+	detail := glib.QuarkFromString("a")
+	if found := obj.HandlerFind(mask, computeSignal, detail, nil, nil, nil); found != handle {
+		t.Fatalf("expected to find handler %d, got %d", handle, found)
+	}
+	if n := obj.HandlersDisconnectMatched(mask, computeSignal, detail, nil, nil, nil); n != 1 {
+		t.Fatalf("expected one handler to be disconnected, got %d", n)
+	}
+	if obj.HandlerIsConnected(handle) {
+		t.Fatal("expected the handler to be disconnected")
+	}
+
+	// handlers connected from go are matched by their function
+	double := func(obj *glib.Object, in int) int { return 2 * in }
+	handle, err = obj.Connect("compute::a", double)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := obj.Connect("compute::a", func(obj *glib.Object, in int) int { return -in })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found := obj.HandlerFindFunc(glib.SIGNAL_MATCH_ID, computeSignal, 0, double); found != handle {
+		t.Fatalf("expected to find handler %d, got %d", handle, found)
+	}
+	if found := obj.HandlerFindFunc(glib.SIGNAL_MATCH_UNBLOCKED, nil, 0, t.Log); found != 0 {
+		t.Fatalf("expected no handler for an unconnected func, got %d", found)
+	}
+	if n := obj.HandlersDisconnectFunc(0, nil, 0, double); n != 1 {
+		t.Fatalf("expected one handler to be disconnected, got %d", n)
+	}
+	if obj.HandlerIsConnected(handle) || !obj.HandlerIsConnected(other) {
+		t.Fatal("expected only the handler of double to be disconnected")
+	}
+}
+
+func TestTypeSignals(t *testing.T) {
+	typ := glib.RegisterGoType("GoGlibSignalTestObject", &signalTestObject{}, glib.ExtendsObject)
+
+	signals := typ.Signals()
+	if len(signals) != 1 {
+		t.Fatalf("expected 1 signal, got %d", len(signals))
+	}
+	compute := signals[0]
+	if compute.Name != "compute" || compute.OwnerType != typ || compute.ReturnType != glib.TYPE_INT ||
+		len(compute.ParamTypes) != 1 || compute.ParamTypes[0] != glib.TYPE_INT || !compute.Flags.Has(glib.SIGNAL_DETAILED) {
+		t.Fatalf("unexpected signal: %+v", compute)
+	}
+
+	// signals of parent types are found as well
+	notify, err := glib.SignalLookup("notify", typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := notify.Query(); q == nil || q.OwnerType != glib.TYPE_OBJECT {
+		t.Fatalf("unexpected query for notify: %+v", q)
+	}
+
+	if _, err := glib.SignalLookup("does-not-exist", typ); !errors.Is(err, glib.ErrSignalNotFound) {
+		t.Fatalf("expected ErrSignalNotFound, got %v", err)
+	}
+	if glib.TYPE_INT.Signals() != nil {
+		t.Fatal("expected fundamental types to have no signals")
+	}
+}
@@ -29,3 +29,9 @@ func MainCurrentSource() *Source {
 	}
 	return (*Source)(c)
 }
+
+// Attach is a wrapper around g_source_attach(). It attaches the source to ctx, or the
+// global default context if ctx is nil, and returns its handle in the context.
+func (v *Source) Attach(ctx *MainContext) SourceHandle {
+	return SourceHandle(C.g_source_attach(v.native(), ctx.native()))
+}
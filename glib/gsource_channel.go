@@ -0,0 +1,168 @@
+package glib
+
+/*
+#include <glib.h>
+#include "glib.go.h"
+
+extern gboolean goChannelSourceDispatch (gpointer data);
+extern void     goChannelSourceFinalize (gpointer data);
+
+typedef struct {
+	GSource  source;
+	gpointer data;
+} GoChannelSource;
+
+static gboolean _go_channel_source_dispatch (GSource * source, GSourceFunc callback, gpointer user_data)
+{
+	// disarm before running the go side, which lets the producer arm the source again
+	g_source_set_ready_time(source, -1);
+	return goChannelSourceDispatch(((GoChannelSource *) source)->data);
+}
+
+static void _go_channel_source_finalize (GSource * source)
+{
+	goChannelSourceFinalize(((GoChannelSource *) source)->data);
+}
+
+static GSourceFuncs _go_channel_source_funcs = {
+	NULL,
+	NULL,
+	_go_channel_source_dispatch,
+	_go_channel_source_finalize,
+};
+
+static GSource * _go_channel_source_new (gpointer data)
+{
+	GSource * source = g_source_new(&_go_channel_source_funcs, sizeof(GoChannelSource));
+	((GoChannelSource *) source)->data = data;
+	g_source_set_name(source, "go channel source");
+	return source;
+}
+*/
+import "C"
+
+import (
+	"sync"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+// ChannelSourceNew creates a source that calls f on the thread running its context for every
+// value received from ch, until f returns false or ch is closed. A goroutine receives the values
+// and wakes up the context with g_source_set_ready_time(), so the context does not poll ch. The
+// goroutine receives the next value only after f returned for the previous one, and exits when
+// the source is finalized.
+//
+// The source must be attached to a context with Attach and released with Unref.
+//
+// Panics in f are recovered and reported to the MarshalErrorHandler, and the source is removed.
+func ChannelSourceNew[T any](ch <-chan T, f func(T) bool) *Source {
+	return channelSourceNew(ch, f, nil)
+}
+
+// channelSourceNew creates a channel source that calls onFinalize, if not nil, when the
+// source is finalized.
+func channelSourceNew[T any](ch <-chan T, f func(T) bool, onFinalize func()) *Source {
+	s := &channelSource[T]{
+		ch:         ch,
+		f:          f,
+		onFinalize: onFinalize,
+		next:       make(chan channelItem[T], 1),
+		ack:        make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+	s.src = C._go_channel_source_new(C.gpointer(gopointer.Save(channelDispatcher(s))))
+
+	go s.receive()
+	return (*Source)(s.src)
+}
+
+// ChannelAdd adds a source receiving from ch to mainCtx, or the global default context if
+// mainCtx is nil, see ChannelSourceNew.
+func ChannelAdd[T any](mainCtx *MainContext, ch <-chan T, f func(T) bool) SourceHandle {
+	src := ChannelSourceNew(ch, f)
+	hdl := src.Attach(mainCtx)
+	src.Unref()
+	return hdl
+}
+
+// channelDispatcher is the type stored for the exported callbacks of a channel source,
+// which cannot be generic.
+type channelDispatcher interface {
+	dispatch() bool
+	finalize()
+}
+
+type channelItem[T any] struct {
+	value T
+	ok    bool
+}
+
+type channelSource[T any] struct {
+	ch         <-chan T
+	f          func(T) bool
+	onFinalize func()
+
+	next chan channelItem[T] // the value received by the goroutine
+	ack  chan struct{}       // signals that the value was dispatched
+	stop chan struct{}       // closed when the source is finalized
+
+	mu      sync.Mutex // guards src against finalization
+	src     *C.GSource
+	stopped bool
+}
+
+// receive forwards the values of the channel one at a time.
+func (s *channelSource[T]) receive() {
+	for {
+		var item channelItem[T]
+		select {
+		case item.value, item.ok = <-s.ch:
+		case <-s.stop:
+			return
+		}
+
+		s.next <- item
+		s.mu.Lock()
+		if !s.stopped {
+			C.g_source_set_ready_time(s.src, 0)
+		}
+		s.mu.Unlock()
+
+		if !item.ok {
+			return
+		}
+		select {
+		case <-s.ack:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// dispatch runs on the thread of the context and reports whether to keep the source.
+func (s *channelSource[T]) dispatch() bool {
+	var item channelItem[T]
+	select {
+	case item = <-s.next:
+	default:
+		// woken up without a value, e.g. by attaching the source
+		return true
+	}
+	if !item.ok || !s.f(item.value) {
+		return false
+	}
+	s.ack <- struct{}{}
+	return true
+}
+
+func (s *channelSource[T]) finalize() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+	close(s.stop)
+
+	if s.onFinalize != nil {
+		s.onFinalize()
+	}
+}
@@ -0,0 +1,25 @@
+package glib
+
+// #include "glib.go.h"
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+//export goChannelSourceDispatch
+func goChannelSourceDispatch(data C.gpointer) (ret C.gboolean) {
+	ret = C.G_SOURCE_REMOVE
+	defer recoverMarshalPanic()
+
+	s := gopointer.Restore(unsafe.Pointer(data)).(channelDispatcher)
+	return gbool(s.dispatch())
+}
+
+//export goChannelSourceFinalize
+func goChannelSourceFinalize(data C.gpointer) {
+	gopointer.Restore(unsafe.Pointer(data)).(channelDispatcher).finalize()
+	gopointer.Unref(unsafe.Pointer(data))
+}
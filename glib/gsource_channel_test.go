@@ -0,0 +1,54 @@
+package glib_test
+
+import (
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+func TestChannelSource(t *testing.T) {
+	ctx := glib.MainContextNew()
+	defer ctx.Unref()
+
+	ch := make(chan int)
+	var got []int
+	hdl := glib.ChannelAdd(ctx, ch, func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+
+	for ctx.FindSourceById(hdl) != nil {
+		ctx.Iteration(true)
+	}
+	if len(got) != 100 {
+		t.Fatalf("received %d values, want 100", len(got))
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("received %d at position %d", v, i)
+		}
+	}
+
+	// returning false removes the source without draining the channel
+	words := make(chan string, 2)
+	words <- "a"
+	words <- "b"
+	var calls int
+	hdl = glib.ChannelAdd(ctx, words, func(string) bool {
+		calls++
+		return false
+	})
+	for ctx.FindSourceById(hdl) != nil {
+		ctx.Iteration(true)
+	}
+	if calls != 1 {
+		t.Fatalf("f was called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,182 @@
+//go:build !windows
+// +build !windows
+
+package glib
+
+/*
+#include <glib.h>
+#include <glib-unix.h>
+#include "glib.go.h"
+
+extern gboolean goUnixFDSourceFunc  (gint fd, GIOCondition condition, gpointer data);
+extern void     goUnixSourceDestroy (gpointer data);
+
+static void _g_source_set_unix_fd_callback (GSource * source, gpointer data)
+{
+	g_source_set_callback(source, (GSourceFunc) goUnixFDSourceFunc, data, goUnixSourceDestroy);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+// IOCondition is a go representation of GIOCondition.
+type IOCondition int
+
+const (
+	IO_IN   IOCondition = C.G_IO_IN   // there is data to read
+	IO_OUT  IOCondition = C.G_IO_OUT  // data can be written without blocking
+	IO_PRI  IOCondition = C.G_IO_PRI  // there is urgent data to read
+	IO_ERR  IOCondition = C.G_IO_ERR  // error condition
+	IO_HUP  IOCondition = C.G_IO_HUP  // hung up, e.g. the write end of a pipe was closed
+	IO_NVAL IOCondition = C.G_IO_NVAL // invalid request, the file descriptor is not open
+)
+
+// UnixFDSourceNew is a wrapper around g_unix_fd_source_new(). The source calls f with fd and
+// the conditions that are met whenever fd satisfies one of condition, until f returns false.
+// IO_ERR, IO_HUP and IO_NVAL are always reported. The source must be attached to a context
+// with Attach and released with Unref.
+//
+// Panics in f are recovered and reported to the MarshalErrorHandler, and the source is removed.
+func UnixFDSourceNew(fd int, condition IOCondition, f func(fd int, condition IOCondition) bool) *Source {
+	src := C.g_unix_fd_source_new(C.gint(fd), C.GIOCondition(condition))
+	C._g_source_set_unix_fd_callback(src, C.gpointer(gopointer.Save(f)))
+	return (*Source)(src)
+}
+
+// UnixFDAdd adds a source watching fd to the default main event loop context, see
+// UnixFDSourceNew. The file descriptor is not closed when the source is removed.
+func UnixFDAdd(fd int, condition IOCondition, f func(fd int, condition IOCondition) bool) SourceHandle {
+	return attachUnixSource(nil, UnixFDSourceNew(fd, condition, f))
+}
+
+// UnixFDAdd adds a source watching fd to the context, see the package level UnixFDAdd.
+func (v *MainContext) UnixFDAdd(fd int, condition IOCondition, f func(fd int, condition IOCondition) bool) SourceHandle {
+	return attachUnixSource(v, UnixFDSourceNew(fd, condition, f))
+}
+
+// UnixSignalSourceNew creates a source that calls f from the main loop every time signum is
+// received, until f returns false. The signal is delivered with os/signal.Notify, so other
+// channels registered for signum keep receiving it, and it is no longer handled by the default
+// action, e.g. terminating the process for SIGINT, while the source exists. The source must be
+// attached to a context with Attach and released with Unref.
+//
+// Panics in f are recovered and reported to the MarshalErrorHandler, and the source is removed.
+func UnixSignalSourceNew(signum syscall.Signal, f func() bool) (*Source, error) {
+	switch signum {
+	case syscall.SIGKILL, syscall.SIGSTOP:
+		return nil, fmt.Errorf("signal %v cannot be caught", signum)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signum)
+	return channelSourceNew(ch, func(os.Signal) bool { return f() }, func() { signal.Stop(ch) }), nil
+}
+
+// UnixSignalAdd adds a source handling signum to the default main event loop context,
+// see UnixSignalSourceNew.
+func UnixSignalAdd(signum syscall.Signal, f func() bool) (SourceHandle, error) {
+	return unixSignalAdd(nil, signum, f)
+}
+
+// UnixSignalAdd adds a source handling signum to the context, see the package level UnixSignalAdd.
+func (v *MainContext) UnixSignalAdd(signum syscall.Signal, f func() bool) (SourceHandle, error) {
+	return unixSignalAdd(v, signum, f)
+}
+
+func unixSignalAdd(ctx *MainContext, signum syscall.Signal, f func() bool) (SourceHandle, error) {
+	src, err := UnixSignalSourceNew(signum, f)
+	if err != nil {
+		return 0, err
+	}
+	return attachUnixSource(ctx, src), nil
+}
+
+// ChildWatchSourceNew creates a source that calls f once with pid and its wait status when
+// the child process pid exits, and is removed afterwards. The child is reaped by a goroutine
+// waiting for it, so it must not be waited for otherwise, e.g. with os.Process.Wait or
+// exec.Cmd.Wait, and removing the source does not stop the goroutine. If waiting fails, for
+// example because pid is not a child of the process, the error is reported to the
+// MarshalErrorHandler and the source is removed without calling f. The source must be
+// attached to a context with Attach and released with Unref.
+//
+// Panics in f are recovered and reported to the MarshalErrorHandler.
+func ChildWatchSourceNew(pid int, f func(pid int, status syscall.WaitStatus)) *Source {
+	exited := make(chan syscall.WaitStatus, 1)
+	go func() {
+		defer close(exited)
+
+		var status syscall.WaitStatus
+		for {
+			_, err := syscall.Wait4(pid, &status, 0, nil)
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil {
+				reportMarshalError(fmt.Errorf("waiting for child %d: %w", pid, err))
+				return
+			}
+			break
+		}
+		exited <- status
+	}()
+
+	return channelSourceNew(exited, func(status syscall.WaitStatus) bool {
+		f(pid, status)
+		return false
+	}, nil)
+}
+
+// ChildWatchAdd adds a source watching the child process pid to the default main event
+// loop context, see ChildWatchSourceNew.
+func ChildWatchAdd(pid int, f func(pid int, status syscall.WaitStatus)) SourceHandle {
+	return attachUnixSource(nil, ChildWatchSourceNew(pid, f))
+}
+
+// ChildWatchAdd adds a source watching the child process pid to the context, see the
+// package level ChildWatchAdd.
+func (v *MainContext) ChildWatchAdd(pid int, f func(pid int, status syscall.WaitStatus)) SourceHandle {
+	return attachUnixSource(v, ChildWatchSourceNew(pid, f))
+}
+
+// attachUnixSource attaches src to ctx and passes the reference of src to the context.
+func attachUnixSource(ctx *MainContext, src *Source) SourceHandle {
+	hdl := src.Attach(ctx)
+	src.Unref()
+	return hdl
+}
+
+// UnixFDTag identifies a file descriptor added to a source with AddUnixFD.
+type UnixFDTag struct {
+	tag C.gpointer
+}
+
+// AddUnixFD is a wrapper around g_source_add_unix_fd(). It makes the source poll fd
+// for events in addition to its own conditions, so the context wakes up when fd becomes
+// ready. Use QueryUnixFD to find out which events occurred.
+func (v *Source) AddUnixFD(fd int, events IOCondition) UnixFDTag {
+	return UnixFDTag{C.g_source_add_unix_fd(v.native(), C.gint(fd), C.GIOCondition(events))}
+}
+
+// ModifyUnixFD is a wrapper around g_source_modify_unix_fd().
+func (v *Source) ModifyUnixFD(tag UnixFDTag, events IOCondition) {
+	C.g_source_modify_unix_fd(v.native(), tag.tag, C.GIOCondition(events))
+}
+
+// RemoveUnixFD is a wrapper around g_source_remove_unix_fd().
+func (v *Source) RemoveUnixFD(tag UnixFDTag) {
+	C.g_source_remove_unix_fd(v.native(), tag.tag)
+}
+
+// QueryUnixFD is a wrapper around g_source_query_unix_fd(). It returns the events of
+// the file descriptor that occurred in the last poll of the context.
+func (v *Source) QueryUnixFD(tag UnixFDTag) IOCondition {
+	return IOCondition(C.g_source_query_unix_fd(v.native(), tag.tag))
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+// +build !windows
+
+package glib
+
+// #include <glib.h>
+// #include "glib.go.h"
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+//export goUnixFDSourceFunc
+func goUnixFDSourceFunc(fd C.gint, condition C.GIOCondition, data C.gpointer) (ret C.gboolean) {
+	ret = C.G_SOURCE_REMOVE
+	defer recoverMarshalPanic()
+
+	f := gopointer.Restore(unsafe.Pointer(data)).(func(int, IOCondition) bool)
+	return gbool(f(int(fd), IOCondition(condition)))
+}
+
+//export goUnixSourceDestroy
+func goUnixSourceDestroy(data C.gpointer) {
+	gopointer.Unref(unsafe.Pointer(data))
+}
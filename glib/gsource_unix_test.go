@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+package glib_test
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+func TestUnixSources(t *testing.T) {
+	ctx := glib.MainContextNew()
+	defer ctx.Unref()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var read []byte
+	var hungUp bool
+	ctx.UnixFDAdd(int(r.Fd()), glib.IO_IN, func(fd int, cond glib.IOCondition) bool {
+		if cond&glib.IO_IN != 0 {
+			buf := make([]byte, 16)
+			n, _ := syscall.Read(fd, buf)
+			read = append(read, buf[:n]...)
+		}
+		hungUp = cond&glib.IO_HUP != 0
+		return !hungUp
+	})
+
+	w.Write([]byte("ping"))
+	w.Close()
+	for !hungUp {
+		ctx.Iteration(true)
+	}
+	if string(read) != "ping" {
+		t.Fatalf("read %q from the pipe, want %q", read, "ping")
+	}
+
+	if _, err := ctx.UnixSignalAdd(syscall.SIGKILL, func() bool { return false }); err == nil {
+		t.Fatal("expected an error for SIGKILL")
+	}
+	var signals int
+	if _, err := ctx.UnixSignalAdd(syscall.SIGUSR1, func() bool {
+		signals++
+		return false
+	}); err != nil {
+		t.Fatal(err)
+	}
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	for signals == 0 {
+		ctx.Iteration(true)
+	}
+
+	cmd := exec.Command("sh", "-c", "exit 3")
+	if err := cmd.Start(); err != nil {
+		t.Skip(err)
+	}
+	exited := false
+	hdl := ctx.ChildWatchAdd(cmd.Process.Pid, func(pid int, status syscall.WaitStatus) {
+		exited = true
+		if pid != cmd.Process.Pid || status.ExitStatus() != 3 {
+			t.Errorf("child %d exited with %d, want %d and 3", pid, status.ExitStatus(), cmd.Process.Pid)
+		}
+	})
+	for !exited {
+		ctx.Iteration(true)
+	}
+	if ctx.FindSourceById(hdl) != nil {
+		t.Fatal("expected the child watch to be removed after the child exited")
+	}
+
+	// a removed child watch is not called
+	cmd = exec.Command("sh", "-c", "exit 0")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	hdl = ctx.ChildWatchAdd(cmd.Process.Pid, func(int, syscall.WaitStatus) { t.Error("removed child watch was called") })
+	if !ctx.RemoveSource(hdl) {
+		t.Fatal("expected the child watch to be removable")
+	}
+	for ctx.Iteration(false) {
+	}
+}
@@ -0,0 +1,130 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+extern void goToggleNotify (gpointer data, GObject * object, gboolean is_last_ref);
+
+static void cgoToggleNotify (gpointer data, GObject * object, gboolean is_last_ref)
+{
+	goToggleNotify(data, object, is_last_ref);
+}
+
+static void _g_object_add_toggle_ref    (GObject * object) { g_object_add_toggle_ref(object, cgoToggleNotify, NULL); }
+static void _g_object_remove_toggle_ref (GObject * object) { g_object_remove_toggle_ref(object, cgoToggleNotify, NULL); }
+
+static guint _g_object_ref_count (GObject * object) { return g_atomic_int_get(&object->ref_count); }
+*/
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// toggleRef tracks the toggle reference go holds on a GObject.
+type toggleRef struct {
+	// foreign is true while references other than the toggle reference exist.
+	foreign bool
+	// wrappers is the number of live go wrappers sharing the toggle reference.
+	wrappers int
+	// keep holds the wrappers that were collected by go while foreign references existed.
+	keep []*Object
+}
+
+var toggleRefs = struct {
+	sync.Mutex
+	m map[*C.GObject]*toggleRef
+}{
+	m: make(map[*C.GObject]*toggleRef),
+}
+
+// TakeToggle wraps a unsafe.Pointer as a glib.Object, taking ownership of it with a toggle reference.
+// A floating reference is sunk.
+//
+// Unlike Take, the returned Object does not keep the GObject alive on its own: while other references
+// to the GObject exist, the go wrapper is kept alive by the toggle reference, and once go holds the only
+// reference, the GObject is freed together with the wrapper when the go garbage collector collects it.
+// This breaks cycles between GObjects and go closures referencing them.
+//
+// All wrappers of a GObject created with TakeToggle share a single toggle reference, it is accounted for
+// in the go-glib-reffed-objects profile. Unref must not be called on the returned Object.
+func TakeToggle(ptr unsafe.Pointer) *Object {
+	gobj := ToGObject(ptr)
+
+	toggleRefs.Lock()
+	defer toggleRefs.Unlock()
+
+	ref, ok := toggleRefs.m[gobj]
+	if ok {
+		ref.wrappers++
+	} else {
+		floating := gobool(C.g_object_is_floating(C.gpointer(gobj)))
+		if floating {
+			C.g_object_ref_sink(C.gpointer(gobj))
+		}
+		C._g_object_add_toggle_ref(gobj)
+
+		ref = &toggleRef{foreign: true, wrappers: 1}
+		toggleRefs.m[gobj] = ref
+		gObjectProfile.Add(uintptr(unsafe.Pointer(gobj)), 1)
+
+		if floating {
+			// dropping the sunk reference calls toggleNotify, which takes the lock
+			toggleRefs.Unlock()
+			C.g_object_unref(C.gpointer(gobj))
+			toggleRefs.Lock()
+		}
+		ref.foreign = C._g_object_ref_count(gobj) > 1
+	}
+
+	obj := newObject(gobj)
+	runtime.SetFinalizer(obj, finalizeToggleRef)
+	return obj
+}
+
+// finalizeToggleRef is the finalizer of wrappers created by TakeToggle. The wrapper is kept alive if
+// references other than the toggle reference exist, the toggle reference is removed once the last
+// wrapper is gone.
+func finalizeToggleRef(obj *Object) {
+	toggleRefs.Lock()
+
+	ref := toggleRefs.m[obj.GObject]
+	if ref.foreign {
+		ref.keep = append(ref.keep, obj)
+		runtime.SetFinalizer(obj, finalizeToggleRef)
+		toggleRefs.Unlock()
+		return
+	}
+
+	ref.wrappers--
+	if ref.wrappers > 0 {
+		toggleRefs.Unlock()
+		return
+	}
+	delete(toggleRefs.m, obj.GObject)
+	toggleRefs.Unlock()
+
+	gObjectProfile.Remove(uintptr(unsafe.Pointer(obj.GObject)))
+	// this may finalize the object, so it is called without holding the lock
+	C._g_object_remove_toggle_ref(obj.GObject)
+}
+
+// toggleNotify is called when the toggle reference becomes the last reference or stops being it.
+func toggleNotify(gobj *C.GObject, isLastRef bool) {
+	toggleRefs.Lock()
+	defer toggleRefs.Unlock()
+
+	ref, ok := toggleRefs.m[gobj]
+	if !ok {
+		// the toggle reference is being removed
+		return
+	}
+
+	ref.foreign = !isLastRef
+	if isLastRef {
+		// the kept wrappers may be collected now, which removes the toggle reference
+		ref.keep = nil
+	}
+}
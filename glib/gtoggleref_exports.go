@@ -0,0 +1,11 @@
+package glib
+
+/*
+#include "glib.go.h"
+*/
+import "C"
+
+//export goToggleNotify
+func goToggleNotify(_ C.gpointer, object *C.GObject, isLastRef C.gboolean) {
+	toggleNotify(object, gobool(isLastRef))
+}
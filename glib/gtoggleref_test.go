@@ -0,0 +1,81 @@
+package glib_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+// collectUntil runs the garbage collector until cond is true or it gives up.
+func collectUntil(cond func() bool) bool {
+	for i := 0; i < 50; i++ {
+		if cond() {
+			return true
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestTakeToggle(t *testing.T) {
+	obj, err := glib.NewObjectWithProperties(glib.TYPE_OBJECT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var finalized atomic.Bool
+	obj.WeakRef(func() { finalized.Store(true) })
+
+	toggled := glib.TakeToggle(obj.Unsafe())
+	releaseObject(obj)
+
+	// a reference held outside of go keeps the object alive after the wrapper became unreachable
+	foreign := glib.Take(toggled.Unsafe())
+	toggled = nil
+
+	if collectUntil(finalized.Load) {
+		t.Fatal("object was finalized while a foreign reference existed")
+	}
+
+	releaseObject(foreign)
+
+	if !collectUntil(finalized.Load) {
+		t.Fatal("expected the object to be finalized once go held the only reference")
+	}
+}
+
+func TestTakeToggleFloating(t *testing.T) {
+	obj, err := glib.NewObjectWithProperties(glib.TYPE_OBJECT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var finalized atomic.Bool
+	obj.WeakRef(func() { finalized.Store(true) })
+
+	// the floating reference is sunk and replaced by the toggle reference
+	runtime.SetFinalizer(obj, nil)
+	obj.ForceFloating()
+
+	taken := make(chan *glib.Object)
+	go func() { taken <- glib.TakeToggle(obj.Unsafe()) }()
+
+	var toggled *glib.Object
+	select {
+	case toggled = <-taken:
+	case <-time.After(5 * time.Second):
+		t.Fatal("TakeToggle did not return for a floating object")
+	}
+	if toggled.IsFloating() {
+		t.Fatal("expected the floating reference to be sunk")
+	}
+	obj, toggled = nil, nil
+
+	if !collectUntil(finalized.Load) {
+		t.Fatal("expected the object to be finalized once go held the only reference")
+	}
+}
@@ -0,0 +1,163 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+static gboolean _g_type_is_final (GType type)
+{
+#if GLIB_CHECK_VERSION(2, 70, 0)
+	return G_TYPE_IS_FINAL(type);
+#else
+	return FALSE;
+#endif
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+// Fundamental is a wrapper around g_type_fundamental().
+func (t Type) Fundamental() Type {
+	return Type(C.g_type_fundamental(C.GType(t)))
+}
+
+// Children is a wrapper around g_type_children(). It returns the direct children of the type.
+func (t Type) Children() []Type {
+	var n C.guint
+	return typeArray(C.g_type_children(C.GType(t), &n), n)
+}
+
+// Interfaces is a wrapper around g_type_interfaces(). It returns the interfaces implemented by the type
+// itself, the interfaces of its ancestors are not included.
+func (t Type) Interfaces() []Type {
+	var n C.guint
+	return typeArray(C.g_type_interfaces(C.GType(t), &n), n)
+}
+
+// Prerequisites is a wrapper around g_type_interface_prerequisites(). It returns the prerequisites of an
+// interface type.
+func (t Type) Prerequisites() []Type {
+	if t.Fundamental() != TYPE_INTERFACE {
+		return nil
+	}
+	var n C.guint
+	return typeArray(C.g_type_interface_prerequisites(C.GType(t), &n), n)
+}
+
+// typeArray converts and frees an array of GTypes returned by GLib.
+func typeArray(types *C.GType, n C.guint) []Type {
+	if types == nil {
+		return nil
+	}
+	defer C.g_free(C.gpointer(types))
+
+	out := make([]Type, 0, int(n))
+	for _, t := range unsafe.Slice(types, n) {
+		out = append(out, Type(t))
+	}
+	return out
+}
+
+// IsAbstract is a wrapper around G_TYPE_IS_ABSTRACT().
+func (t Type) IsAbstract() bool {
+	return gobool(C.g_type_test_flags(C.GType(t), C.G_TYPE_FLAG_ABSTRACT))
+}
+
+// IsFinal is a wrapper around G_TYPE_IS_FINAL(). It always returns false before GLib 2.70.
+func (t Type) IsFinal() bool {
+	return gobool(C._g_type_is_final(C.GType(t)))
+}
+
+// IsDerivable is a wrapper around G_TYPE_IS_DERIVABLE().
+func (t Type) IsDerivable() bool {
+	return gobool(C.g_type_test_flags(C.GType(t), C.G_TYPE_FLAG_DERIVABLE))
+}
+
+// IsInstantiatable is a wrapper around G_TYPE_IS_INSTANTIATABLE().
+func (t Type) IsInstantiatable() bool {
+	return gobool(C.g_type_test_flags(C.GType(t), C.G_TYPE_FLAG_INSTANTIATABLE))
+}
+
+// Properties returns the properties of an object or interface type, without needing an instance.
+// The default values of the returned ParamSpecs are the defaults of the properties. Properties of
+// ancestors are included for object types.
+func (t Type) Properties() []*ParamSpec {
+	var props **C.GParamSpec
+	var n C.guint
+
+	switch {
+	case t.IsA(TYPE_OBJECT):
+		class := C.g_type_class_ref(C.GType(t))
+		defer C.g_type_class_unref(class)
+		props = C.g_object_class_list_properties((*C.GObjectClass)(class), &n)
+	case t.Fundamental() == TYPE_INTERFACE:
+		iface := C.g_type_default_interface_ref(C.GType(t))
+		defer C.g_type_default_interface_unref(iface)
+		props = C.g_object_interface_list_properties(iface, &n)
+	default:
+		return nil
+	}
+	if props == nil {
+		return nil
+	}
+	defer C.g_free(C.gpointer(props))
+
+	out := make([]*ParamSpec, 0, int(n))
+	for _, prop := range unsafe.Slice(props, n) {
+		C.g_param_spec_ref(prop)
+		ps := newParamSpec(prop)
+		runtime.SetFinalizer(ps, (*ParamSpec).Unref)
+		out = append(out, ps)
+	}
+	return out
+}
+
+// SetQdata is a wrapper around g_type_set_qdata(). It attaches data to the type, replacing the data
+// previously set for the quark. Types are never unloaded, so the data is kept until it is replaced.
+func (t Type) SetQdata(quark Quark, data interface{}) {
+	old := C.g_type_get_qdata(C.GType(t), C.GQuark(quark))
+
+	var ptr unsafe.Pointer
+	if data != nil {
+		ptr = gopointer.Save(data)
+	}
+	C.g_type_set_qdata(C.GType(t), C.GQuark(quark), C.gpointer(ptr))
+
+	// data set from C is not managed by go
+	if old != nil && gopointer.Restore(unsafe.Pointer(old)) != nil {
+		gopointer.Unref(unsafe.Pointer(old))
+	}
+}
+
+// Qdata is a wrapper around g_type_get_qdata(). It returns the data attached with SetQdata, or nil
+// if no data or data not set from go is attached.
+func (t Type) Qdata(quark Quark) interface{} {
+	ptr := C.g_type_get_qdata(C.GType(t), C.GQuark(quark))
+	if ptr == nil {
+		return nil
+	}
+	return gopointer.Restore(unsafe.Pointer(ptr))
+}
+
+func (t Type) isClassed() bool {
+	return gobool(C.g_type_test_flags(C.GType(t), C.G_TYPE_FLAG_CLASSED))
+}
+
+// refTypeClass makes sure the class or default interface of t is initialized until the returned
+// function is called.
+func refTypeClass(t Type) func() {
+	switch {
+	case t.isClassed():
+		class := C.g_type_class_ref(C.GType(t))
+		return func() { C.g_type_class_unref(class) }
+	case Type(C.g_type_fundamental(C.GType(t))) == TYPE_INTERFACE:
+		iface := C.g_type_default_interface_ref(C.GType(t))
+		return func() { C.g_type_default_interface_unref(iface) }
+	}
+	return func() {}
+}
@@ -0,0 +1,56 @@
+package glib_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+// typeTestInterface is registered once, as types stay registered when the test runs again in the
+// same process.
+var typeTestInterface *glib.GoInterface
+
+func TestTypeIntrospection(t *testing.T) {
+	if typeTestInterface == nil {
+		var err error
+		typeTestInterface, err = glib.RegisterInterface("GoGlibTypeTestInterface", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	iface := typeTestInterface
+	typ := glib.RegisterGoType("GoGlibStructPropertiesObject", &structPropertiesObject{}, glib.ExtendsObject)
+
+	if typ.Fundamental() != glib.TYPE_OBJECT || !typ.IsInstantiatable() || !typ.IsDerivable() || typ.IsAbstract() || typ.IsFinal() {
+		t.Fatalf("unexpected flags for %s", typ.Name())
+	}
+	if !glib.TYPE_OBJECT.IsDerivable() || glib.TYPE_INT.IsInstantiatable() {
+		t.Fatal("unexpected flags for fundamental types")
+	}
+	if !slices.Contains(glib.TYPE_OBJECT.Children(), typ) {
+		t.Fatalf("expected %s to be a child of GObject", typ.Name())
+	}
+
+	if prerequisites := iface.Type().Prerequisites(); len(prerequisites) != 1 || prerequisites[0] != glib.TYPE_OBJECT {
+		t.Fatalf("unexpected prerequisites: %v", prerequisites)
+	}
+	if len(typ.Interfaces()) != 0 {
+		t.Fatal("expected no interfaces")
+	}
+
+	props := typ.Properties()
+	if len(props) != 4 {
+		t.Fatalf("expected 4 properties, got %d", len(props))
+	}
+
+	quark := glib.QuarkFromString("go-glib-type-test")
+	typ.SetQdata(quark, "data")
+	if data := typ.Qdata(quark); data != "data" {
+		t.Fatalf("expected qdata, got %v", data)
+	}
+	typ.SetQdata(quark, nil)
+	if data := typ.Qdata(quark); data != nil {
+		t.Fatalf("expected qdata to be cleared, got %v", data)
+	}
+}
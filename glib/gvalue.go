@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"unsafe"
 )
@@ -148,6 +150,12 @@ func (v *Value) Type() (actual Type, fundamental Type, err error) {
 
 // GValue converts a Go type to a comparable GValue.  GValue()
 // returns a non-nil error if the conversion was unsuccessful.
+//
+// Besides the basic types, []string is converted to a G_TYPE_STRV, []byte to a
+// TYPE_BYTE_SLICE, [][]byte to a TYPE_BYTE_SLICE_ARRAY, []*Object to a
+// TYPE_OBJECT_ARRAY, map[string]interface{} to an a{sv} GVariant and Type to a
+// G_TYPE_GTYPE. GoValue returns them as the same types. The byte slice and array
+// types can be transformed to GBytes and GValueArrays, see TYPE_BYTE_SLICE.
 func GValue(v interface{}) (*Value, error) {
 	return gValue(v)
 }
@@ -278,9 +286,59 @@ func gValue(v interface{}) (gvalue *Value, err error) {
 		}
 		return val, nil
 
+	case *Variant:
+		val, err := ValueInit(TYPE_VARIANT)
+		if err != nil {
+			return nil, err
+		}
+		val.SetVariant(e)
+		return val, nil
+
+	case Type:
+		val, err := ValueInit(TYPE_GTYPE)
+		if err != nil {
+			return nil, err
+		}
+		val.SetGType(e)
+		return val, nil
+
+	case []string:
+		return strvValue(e)
+
+	case []byte:
+		return byteSliceValue(e)
+
+	case [][]byte:
+		return valueArrayValue(TYPE_BYTE_SLICE_ARRAY, len(e), func(i int) (*Value, error) { return byteSliceValue(e[i]) })
+
+	case []*Object:
+		return valueArrayValue(TYPE_OBJECT_ARRAY, len(e), func(i int) (*Value, error) { return gValue(e[i]) })
+
+	case map[string]interface{}:
+		variant, err := MarshalVariant(e)
+		if err != nil {
+			return nil, err
+		}
+		return gValue(variant)
+
 	default:
 		/* Try this since above doesn't catch constants under other types */
 		rval := reflect.ValueOf(v)
+
+		// go types registered with RegisterEnumType or RegisterFlagsType
+		if t, ok := goEnumType(rval.Type()); ok {
+			val, err := ValueInit(t)
+			if err != nil {
+				return nil, err
+			}
+			if t.IsA(TYPE_FLAGS) {
+				val.SetFlags(uint(rval.Uint()))
+			} else {
+				val.SetEnum(int(rval.Int()))
+			}
+			return val, nil
+		}
+
 		switch rval.Kind() {
 		case reflect.Int8:
 			val, err := ValueInit(TYPE_CHAR)
@@ -290,11 +348,13 @@ func gValue(v interface{}) (gvalue *Value, err error) {
 			val.SetSChar(int8(rval.Int()))
 			return val, nil
 
-		case reflect.Int16:
-			return nil, errors.New("Type not implemented")
-
-		case reflect.Int32:
-			return nil, errors.New("Type not implemented")
+		case reflect.Int16, reflect.Int32:
+			val, err := ValueInit(TYPE_INT)
+			if err != nil {
+				return nil, err
+			}
+			val.SetInt(int(rval.Int()))
+			return val, nil
 
 		case reflect.Int64:
 			val, err := ValueInit(TYPE_INT64)
@@ -312,6 +372,62 @@ func gValue(v interface{}) (gvalue *Value, err error) {
 			val.SetInt(int(rval.Int()))
 			return val, nil
 
+		case reflect.Uint8:
+			val, err := ValueInit(TYPE_UCHAR)
+			if err != nil {
+				return nil, err
+			}
+			val.SetUChar(uint8(rval.Uint()))
+			return val, nil
+
+		case reflect.Uint16, reflect.Uint32, reflect.Uint:
+			val, err := ValueInit(TYPE_UINT)
+			if err != nil {
+				return nil, err
+			}
+			val.SetUInt(uint(rval.Uint()))
+			return val, nil
+
+		case reflect.Uint64:
+			val, err := ValueInit(TYPE_UINT64)
+			if err != nil {
+				return nil, err
+			}
+			val.SetUInt64(rval.Uint())
+			return val, nil
+
+		case reflect.Float32:
+			val, err := ValueInit(TYPE_FLOAT)
+			if err != nil {
+				return nil, err
+			}
+			val.SetFloat(float32(rval.Float()))
+			return val, nil
+
+		case reflect.Float64:
+			val, err := ValueInit(TYPE_DOUBLE)
+			if err != nil {
+				return nil, err
+			}
+			val.SetDouble(rval.Float())
+			return val, nil
+
+		case reflect.String:
+			val, err := ValueInit(TYPE_STRING)
+			if err != nil {
+				return nil, err
+			}
+			val.SetString(rval.String())
+			return val, nil
+
+		case reflect.Bool:
+			val, err := ValueInit(TYPE_BOOLEAN)
+			if err != nil {
+				return nil, err
+			}
+			val.SetBool(rval.Bool())
+			return val, nil
+
 		// reflect.Uintptr looks very dangerous here, this could lead to memory errors
 		case reflect.Uintptr, reflect.Ptr:
 			val, err := ValueInit(TYPE_POINTER)
@@ -327,6 +443,169 @@ func gValue(v interface{}) (gvalue *Value, err error) {
 	return nil, errors.New("Type not implemented")
 }
 
+// ValueTypeTransformable is a wrapper around g_value_type_transformable(). It returns true if values
+// of type src can be transformed to dest with TransformTo.
+func ValueTypeTransformable(src, dest Type) bool {
+	return gobool(C.g_value_type_transformable(C.GType(src), C.GType(dest)))
+}
+
+// TransformTo is a wrapper around g_value_transform(). It returns a new Value of type t holding the
+// content of v.
+//
+// GLib can transform most values to strings, but not the other way around. Strings are therefore
+// parsed if no transformation is registered: booleans and numbers as in strconv, enums by value name,
+// nick or number and flags as a list of value names, nicks or numbers separated by "|".
+func (v *Value) TransformTo(t Type) (*Value, error) {
+	actual, _, err := v.Type()
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err := ValueInit(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if ValueTypeTransformable(actual, t) {
+		if !gobool(C.g_value_transform(v.native(), dest.native())) {
+			return nil, fmt.Errorf("could not transform %s to %s", actual.Name(), t.Name())
+		}
+		return dest, nil
+	}
+
+	if actual.IsA(TYPE_STRING) {
+		s, err := v.GetString()
+		if err != nil {
+			s = ""
+		}
+		if err := dest.parseString(s); err != nil {
+			return nil, fmt.Errorf("could not transform %q to %s: %w", s, t.Name(), err)
+		}
+		return dest, nil
+	}
+
+	return nil, fmt.Errorf("cannot transform %s to %s", actual.Name(), t.Name())
+}
+
+// parseString sets the content of v from the string s, see TransformTo.
+func (v *Value) parseString(s string) error {
+	actual, fundamental, _ := v.Type()
+
+	switch fundamental {
+	case TYPE_BOOLEAN:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case TYPE_CHAR:
+		i, err := strconv.ParseInt(s, 0, 8)
+		if err != nil {
+			return err
+		}
+		v.SetSChar(int8(i))
+	case TYPE_UCHAR:
+		i, err := strconv.ParseUint(s, 0, 8)
+		if err != nil {
+			return err
+		}
+		v.SetUChar(uint8(i))
+	case TYPE_INT:
+		i, err := strconv.ParseInt(s, 0, 32)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int(i))
+	case TYPE_LONG:
+		i, err := strconv.ParseInt(s, 0, int(C.sizeof_glong)*8)
+		if err != nil {
+			return err
+		}
+		v.SetLong(int(i))
+	case TYPE_INT64:
+		i, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt64(i)
+	case TYPE_UINT:
+		i, err := strconv.ParseUint(s, 0, 32)
+		if err != nil {
+			return err
+		}
+		v.SetUInt(uint(i))
+	case TYPE_ULONG:
+		i, err := strconv.ParseUint(s, 0, int(C.sizeof_gulong)*8)
+		if err != nil {
+			return err
+		}
+		v.SetULong(uint(i))
+	case TYPE_UINT64:
+		i, err := strconv.ParseUint(s, 0, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUInt64(i)
+	case TYPE_FLOAT:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(float32(f))
+	case TYPE_DOUBLE:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetDouble(f)
+	case TYPE_ENUM:
+		class := (*C.GEnumClass)(C.g_type_class_ref(C.GType(actual)))
+		defer C.g_type_class_unref(C.gpointer(class))
+
+		cstr := C.CString(s)
+		defer C.free(unsafe.Pointer(cstr))
+
+		if ev := C.g_enum_get_value_by_nick(class, (*C.gchar)(cstr)); ev != nil {
+			v.SetEnum(int(ev.value))
+		} else if ev := C.g_enum_get_value_by_name(class, (*C.gchar)(cstr)); ev != nil {
+			v.SetEnum(int(ev.value))
+		} else if i, err := strconv.ParseInt(s, 0, 32); err == nil && C.g_enum_get_value(class, C.gint(i)) != nil {
+			v.SetEnum(int(i))
+		} else {
+			return fmt.Errorf("unknown value %s", s)
+		}
+	case TYPE_FLAGS:
+		class := (*C.GFlagsClass)(C.g_type_class_ref(C.GType(actual)))
+		defer C.g_type_class_unref(C.gpointer(class))
+
+		var flags uint
+		for _, part := range strings.Split(s, "|") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			cstr := C.CString(part)
+			fv := C.g_flags_get_value_by_nick(class, (*C.gchar)(cstr))
+			if fv == nil {
+				fv = C.g_flags_get_value_by_name(class, (*C.gchar)(cstr))
+			}
+			C.free(unsafe.Pointer(cstr))
+
+			if fv != nil {
+				flags |= uint(fv.value)
+			} else if i, err := strconv.ParseUint(part, 0, 32); err == nil {
+				flags |= uint(i)
+			} else {
+				return fmt.Errorf("unknown flag %s", part)
+			}
+		}
+		v.SetFlags(flags)
+	default:
+		return errors.New("type cannot be parsed from a string")
+	}
+	return nil
+}
+
 // GValueMarshaler is a marshal function to convert a GValue into an
 // appropriate Go type.  The unsafe.Pointer parameter is a *C.GValue.
 type GValueMarshaler func(unsafe.Pointer) (interface{}, error)
@@ -508,11 +787,23 @@ func marshalPointer(p unsafe.Pointer) (interface{}, error) {
 
 func marshalObject(p unsafe.Pointer) (interface{}, error) {
 	c := C.g_value_get_object((*C.GValue)(p))
+	if c == nil {
+		return (*Object)(nil), nil
+	}
 	return Take(unsafe.Pointer(c)), nil
 }
 
+// marshalVariant returns vardicts (a{sv}) as map[string]interface{} and all other variants as *Variant.
 func marshalVariant(p unsafe.Pointer) (interface{}, error) {
-	return nil, errors.New("variant conversion not yet implemented")
+	c := C.g_value_get_variant((*C.GValue)(p))
+	if c == nil {
+		return (*Variant)(nil), nil
+	}
+	v := takeVariant(c)
+	if v.TypeString() == "a{sv}" {
+		return variantToVardict(v), nil
+	}
+	return v, nil
 }
 
 func marshalParam(p unsafe.Pointer) (interface{}, error) {
@@ -626,6 +917,16 @@ func (v *Value) SetParam(p *ParamSpec) {
 	C.g_value_set_param(v.native(), p.paramSpec)
 }
 
+// SetVariant is a wrapper around g_value_set_variant().
+func (v *Value) SetVariant(variant *Variant) {
+	C.g_value_set_variant(v.native(), variant.native())
+}
+
+// SetGType is a wrapper around g_value_set_gtype().
+func (v *Value) SetGType(t Type) {
+	C.g_value_set_gtype(v.native(), C.GType(t))
+}
+
 // SetBoxed is a wrapper around g_value_set_boxed().
 func (v *Value) SetBoxed(p unsafe.Pointer) {
 	C.g_value_set_boxed(v.native(), C.gconstpointer(p))
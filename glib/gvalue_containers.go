@@ -0,0 +1,309 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+// The boxed types of []byte, [][]byte and []*Object share the representation of GBytes and
+// GValueArray, so that GoValue can tell them apart from other values, even if they are empty.
+typedef GBytes      GoGlibByteSlice;
+typedef GValueArray GoGlibByteSliceArray;
+typedef GValueArray GoGlibObjectArray;
+
+static GoGlibByteSlice * go_glib_byte_slice_copy (GoGlibByteSlice * b) { return g_bytes_ref(b); }
+static void              go_glib_byte_slice_free (GoGlibByteSlice * b) { g_bytes_unref(b); }
+static GValueArray *     go_glib_value_array_copy (GValueArray * a)    { return g_value_array_copy(a); }
+
+G_DEFINE_BOXED_TYPE(GoGlibByteSlice, go_glib_byte_slice, go_glib_byte_slice_copy, go_glib_byte_slice_free)
+G_DEFINE_BOXED_TYPE(GoGlibByteSliceArray, go_glib_byte_slice_array, go_glib_value_array_copy, g_value_array_free)
+G_DEFINE_BOXED_TYPE(GoGlibObjectArray, go_glib_object_array, go_glib_value_array_copy, g_value_array_free)
+
+// transforms between boxed types sharing the same representation
+static void go_glib_boxed_alias_transform (const GValue * src, GValue * dest)
+{
+	g_value_set_boxed(dest, g_value_get_boxed(src));
+}
+
+static void go_glib_register_boxed_alias (GType go_type, GType glib_type)
+{
+	g_value_register_transform_func(go_type, glib_type, go_glib_boxed_alias_transform);
+	g_value_register_transform_func(glib_type, go_type, go_glib_boxed_alias_transform);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var (
+	// TYPE_BYTE_SLICE is the type of values created from []byte. It holds a GBytes and can be
+	// transformed to and from G_TYPE_BYTES.
+	TYPE_BYTE_SLICE = Type(C.go_glib_byte_slice_get_type())
+	// TYPE_BYTE_SLICE_ARRAY is the type of values created from [][]byte. It holds a GValueArray of
+	// TYPE_BYTE_SLICE values and can be transformed to and from G_TYPE_VALUE_ARRAY.
+	TYPE_BYTE_SLICE_ARRAY = Type(C.go_glib_byte_slice_array_get_type())
+	// TYPE_OBJECT_ARRAY is the type of values created from []*Object. It holds a GValueArray of
+	// objects and can be transformed to and from G_TYPE_VALUE_ARRAY.
+	TYPE_OBJECT_ARRAY = Type(C.go_glib_object_array_get_type())
+)
+
+func init() {
+	tm := []TypeMarshaler{
+		{TYPE_STRV, marshalStrv},
+		{TYPE_VALUE_ARRAY, marshalValueArray},
+		{TYPE_GTYPE, marshalGType},
+		{TYPE_BYTE_SLICE, marshalByteSlice},
+		{TYPE_BYTE_SLICE_ARRAY, marshalByteSliceArray},
+		{TYPE_OBJECT_ARRAY, marshalObjectArray},
+	}
+
+	RegisterGValueMarshalers(tm)
+
+	for goType, glibType := range boxedAliases {
+		C.go_glib_register_boxed_alias(C.GType(goType), C.GType(glibType))
+	}
+}
+
+// boxedAliases maps the types of go containers to the GLib types sharing their representation.
+var boxedAliases = map[Type]Type{
+	TYPE_BYTE_SLICE:       Type(C.g_bytes_get_type()),
+	TYPE_BYTE_SLICE_ARRAY: TYPE_VALUE_ARRAY,
+	TYPE_OBJECT_ARRAY:     TYPE_VALUE_ARRAY,
+}
+
+// toAliasedType transforms a value of a go container type to t if t is the GLib type it aliases, e.g.
+// a TYPE_BYTE_SLICE to G_TYPE_BYTES, so that it can be passed where t is expected. Other values are
+// returned unchanged.
+func toAliasedType(v *Value, t Type) (*Value, error) {
+	actual, _, err := v.Type()
+	if err != nil || boxedAliases[actual] != t {
+		return v, nil
+	}
+	return v.TransformTo(t)
+}
+
+func marshalGType(p unsafe.Pointer) (interface{}, error) {
+	return Type(C.g_value_get_gtype((*C.GValue)(p))), nil
+}
+
+// strvValue creates a G_TYPE_STRV value holding a copy of strs.
+func strvValue(strs []string) (*Value, error) {
+	val, err := ValueInit(TYPE_STRV)
+	if err != nil {
+		return nil, err
+	}
+
+	// the array is NULL terminated and owned by the value
+	strv := (**C.gchar)(C.g_malloc0(C.gsize(len(strs)+1) * C.gsize(unsafe.Sizeof((*C.gchar)(nil)))))
+	elems := unsafe.Slice(strv, len(strs))
+	for i, s := range strs {
+		cstr := C.CString(s)
+		elems[i] = C.g_strdup((*C.gchar)(cstr))
+		C.free(unsafe.Pointer(cstr))
+	}
+	val.TakeBoxed(unsafe.Pointer(strv))
+	return val, nil
+}
+
+func marshalStrv(p unsafe.Pointer) (interface{}, error) {
+	strv := (**C.gchar)(C.g_value_get_boxed((*C.GValue)(p)))
+	if strv == nil {
+		return []string(nil), nil
+	}
+
+	n := int(C.g_strv_length(strv))
+	out := make([]string, 0, n)
+	for _, s := range unsafe.Slice(strv, n) {
+		out = append(out, C.GoString((*C.char)(s)))
+	}
+	return out, nil
+}
+
+// byteSliceValue creates a TYPE_BYTE_SLICE value holding a copy of b.
+func byteSliceValue(b []byte) (*Value, error) {
+	val, err := ValueInit(TYPE_BYTE_SLICE)
+	if err != nil {
+		return nil, err
+	}
+	val.TakeBoxed(unsafe.Pointer(C.g_bytes_new(C.gconstpointer(unsafe.SliceData(b)), C.gsize(len(b)))))
+	return val, nil
+}
+
+func marshalByteSlice(p unsafe.Pointer) (interface{}, error) {
+	b := (*C.GBytes)(C.g_value_get_boxed((*C.GValue)(p)))
+	if b == nil {
+		return []byte(nil), nil
+	}
+
+	var n C.gsize
+	data := C.g_bytes_get_data(b, &n)
+	return C.GoBytes(unsafe.Pointer(data), C.int(n)), nil
+}
+
+// valueArrayValue creates a value of the GValueArray type t holding n values created by elem.
+func valueArrayValue(t Type, n int, elem func(i int) (*Value, error)) (*Value, error) {
+	arr := C.g_value_array_new(C.guint(n))
+	for i := 0; i < n; i++ {
+		v, err := elem(i)
+		if err != nil {
+			C.g_value_array_free(arr)
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		// the value is copied into the array
+		C.g_value_array_append(arr, v.native())
+	}
+
+	val, err := ValueInit(t)
+	if err != nil {
+		C.g_value_array_free(arr)
+		return nil, err
+	}
+	val.TakeBoxed(unsafe.Pointer(arr))
+	return val, nil
+}
+
+// valueArrayElements returns the go values of the elements of a GValueArray value.
+func valueArrayElements(p unsafe.Pointer) ([]interface{}, error) {
+	arr := (*C.GValueArray)(C.g_value_get_boxed((*C.GValue)(p)))
+	if arr == nil {
+		return nil, nil
+	}
+
+	elems := unsafe.Slice(arr.values, arr.n_values)
+	values := make([]interface{}, 0, len(elems))
+	for i := range elems {
+		v, err := ValueFromNative(unsafe.Pointer(&elems[i])).GoValue()
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// elementBytes returns the data of a []byte or *Bytes element of a GValueArray.
+func elementBytes(v interface{}) ([]byte, bool) {
+	switch b := v.(type) {
+	case []byte:
+		return b, true
+	case *Bytes:
+		return b.Data(), true
+	}
+	return nil, false
+}
+
+func marshalByteSliceArray(p unsafe.Pointer) (interface{}, error) {
+	values, err := valueArrayElements(p)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]byte, 0, len(values))
+	for i, v := range values {
+		b, ok := elementBytes(v)
+		if !ok {
+			return nil, fmt.Errorf("element %d: expected bytes, got %T", i, v)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func marshalObjectArray(p unsafe.Pointer) (interface{}, error) {
+	values, err := valueArrayElements(p)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Object, 0, len(values))
+	for i, v := range values {
+		obj, ok := v.(*Object)
+		if !ok {
+			return nil, fmt.Errorf("element %d: expected an object, got %T", i, v)
+		}
+		out = append(out, obj)
+	}
+	return out, nil
+}
+
+// marshalValueArray returns a GValueArray holding only bytes as [][]byte, one holding only objects as
+// []*Object, and any other, including an empty one, as []interface{}.
+func marshalValueArray(p unsafe.Pointer) (interface{}, error) {
+	values, err := valueArrayElements(p)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return []interface{}(nil), nil
+	}
+
+	allBytes, allObjects := true, true
+	for _, v := range values {
+		_, isBytes := elementBytes(v)
+		_, isObject := v.(*Object)
+		allBytes = allBytes && isBytes
+		allObjects = allObjects && isObject
+	}
+
+	switch {
+	case len(values) == 0:
+		return values, nil
+	case allBytes:
+		return marshalByteSliceArray(p)
+	case allObjects:
+		return marshalObjectArray(p)
+	}
+	return values, nil
+}
+
+// variantToVardict converts an a{sv} variant to a map, see variantToGo for the values.
+func variantToVardict(v *Variant) map[string]interface{} {
+	n := int(C.g_variant_n_children(v.native()))
+	out := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		entry := wrapFullVariant(C.g_variant_get_child_value(v.native(), C.gsize(i)))
+		key := wrapFullVariant(C.g_variant_get_child_value(entry.native(), 0))
+		value := wrapFullVariant(C.g_variant_get_child_value(entry.native(), 1))
+		out[key.GetString()] = variantToGo(value.GetVariant())
+	}
+	return out
+}
+
+// variantToGo converts basic variants, as, ay, a{sv} and v to go values. Other variants are returned
+// as *Variant.
+func variantToGo(v *Variant) interface{} {
+	switch v.TypeString() {
+	case "b":
+		return v.GetBoolean()
+	case "s", "o", "g":
+		return v.GetString()
+	case "n":
+		return int16(C.g_variant_get_int16(v.native()))
+	case "i":
+		return int32(C.g_variant_get_int32(v.native()))
+	case "x":
+		return int64(C.g_variant_get_int64(v.native()))
+	case "y":
+		return uint8(C.g_variant_get_byte(v.native()))
+	case "q":
+		return uint16(C.g_variant_get_uint16(v.native()))
+	case "u":
+		return uint32(C.g_variant_get_uint32(v.native()))
+	case "t":
+		return uint64(C.g_variant_get_uint64(v.native()))
+	case "d":
+		return float64(C.g_variant_get_double(v.native()))
+	case "as":
+		return v.GetStrv()
+	case "ay":
+		var n C.gsize
+		data := C.g_variant_get_fixed_array(v.native(), &n, 1)
+		return C.GoBytes(unsafe.Pointer(data), C.int(n))
+	case "a{sv}":
+		return variantToVardict(v)
+	case "v":
+		return variantToGo(v.GetVariant())
+	}
+	return v
+}
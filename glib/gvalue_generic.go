@@ -0,0 +1,375 @@
+package glib
+
+// #include "glib.go.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// ErrWrongValueType is returned by ValueGet and ValueSet if the type of the Value does not match the
+// go type.
+var ErrWrongValueType = errors.New("wrong value type")
+
+// ValueGet returns the content of v as T. It checks the type of v first and returns an error wrapping
+// ErrWrongValueType if it does not hold a T, instead of panicking like a failed type assertion on the
+// result of GoValue.
+//
+// The go types map to the types of the Value as follows:
+//
+//	bool           TYPE_BOOLEAN
+//	int8, uint8    TYPE_CHAR, TYPE_UCHAR
+//	int32, int64   TYPE_INT, TYPE_INT64 (int64 also TYPE_LONG)
+//	int            TYPE_INT, TYPE_LONG, TYPE_ENUM
+//	uint32, uint64 TYPE_UINT, TYPE_UINT64 (uint64 also TYPE_ULONG)
+//	uint           TYPE_UINT, TYPE_ULONG, TYPE_FLAGS
+//	float32        TYPE_FLOAT
+//	float64        TYPE_DOUBLE
+//	string         TYPE_STRING
+//	[]string       TYPE_STRV
+//	unsafe.Pointer TYPE_POINTER, TYPE_BOXED
+//	*Object        TYPE_OBJECT and interfaces of objects
+//	*ParamSpec     TYPE_PARAM
+//	*Variant       TYPE_VARIANT
+//
+// Types registered with RegisterEnumType and RegisterFlagsType match their GType only. Any other type is
+// converted with GoValue and must be what its marshaler returns.
+func ValueGet[T any](v *Value) (T, error) {
+	var zero T
+
+	actual, fundamental, err := v.Type()
+	if err != nil {
+		return zero, err
+	}
+	mismatch := func() error {
+		return fmt.Errorf("%w: cannot get %T from a value of type %s", ErrWrongValueType, zero, actual.Name())
+	}
+
+	if gtype, ok := goEnumType(reflect.TypeOf(zero)); ok {
+		if actual != gtype {
+			return zero, mismatch()
+		}
+		rv := reflect.New(reflect.TypeOf(zero)).Elem()
+		if fundamental == TYPE_FLAGS {
+			rv.SetUint(uint64(C.g_value_get_flags(v.native())))
+		} else {
+			rv.SetInt(int64(C.g_value_get_enum(v.native())))
+		}
+		return rv.Interface().(T), nil
+	}
+
+	var val interface{}
+	switch any(zero).(type) {
+	case bool:
+		if actual != TYPE_BOOLEAN {
+			return zero, mismatch()
+		}
+		val = gobool(C.g_value_get_boolean(v.native()))
+	case int8:
+		if actual != TYPE_CHAR {
+			return zero, mismatch()
+		}
+		val = int8(C.g_value_get_schar(v.native()))
+	case uint8:
+		if actual != TYPE_UCHAR {
+			return zero, mismatch()
+		}
+		val = uint8(C.g_value_get_uchar(v.native()))
+	case int32:
+		if actual != TYPE_INT {
+			return zero, mismatch()
+		}
+		val = int32(C.g_value_get_int(v.native()))
+	case int:
+		switch {
+		case actual == TYPE_INT:
+			val = int(C.g_value_get_int(v.native()))
+		case actual == TYPE_LONG:
+			val = int(C.g_value_get_long(v.native()))
+		case fundamental == TYPE_ENUM:
+			val = int(C.g_value_get_enum(v.native()))
+		default:
+			return zero, mismatch()
+		}
+	case int64:
+		switch actual {
+		case TYPE_INT64:
+			val = int64(C.g_value_get_int64(v.native()))
+		case TYPE_LONG:
+			val = int64(C.g_value_get_long(v.native()))
+		default:
+			return zero, mismatch()
+		}
+	case uint32:
+		if actual != TYPE_UINT {
+			return zero, mismatch()
+		}
+		val = uint32(C.g_value_get_uint(v.native()))
+	case uint:
+		switch {
+		case actual == TYPE_UINT:
+			val = uint(C.g_value_get_uint(v.native()))
+		case actual == TYPE_ULONG:
+			val = uint(C.g_value_get_ulong(v.native()))
+		case fundamental == TYPE_FLAGS:
+			val = uint(C.g_value_get_flags(v.native()))
+		default:
+			return zero, mismatch()
+		}
+	case uint64:
+		switch actual {
+		case TYPE_UINT64:
+			val = uint64(C.g_value_get_uint64(v.native()))
+		case TYPE_ULONG:
+			val = uint64(C.g_value_get_ulong(v.native()))
+		default:
+			return zero, mismatch()
+		}
+	case float32:
+		if actual != TYPE_FLOAT {
+			return zero, mismatch()
+		}
+		val = float32(C.g_value_get_float(v.native()))
+	case float64:
+		if actual != TYPE_DOUBLE {
+			return zero, mismatch()
+		}
+		val = float64(C.g_value_get_double(v.native()))
+	case string:
+		if actual != TYPE_STRING {
+			return zero, mismatch()
+		}
+		val = C.GoString((*C.char)(C.g_value_get_string(v.native())))
+	case []string:
+		if actual != TYPE_STRV {
+			return zero, mismatch()
+		}
+		val, _ = marshalStrv(unsafe.Pointer(v.native()))
+	case unsafe.Pointer:
+		switch fundamental {
+		case TYPE_POINTER:
+			val = unsafe.Pointer(C.g_value_get_pointer(v.native()))
+		case TYPE_BOXED:
+			val = unsafe.Pointer(C.g_value_get_boxed(v.native()))
+		default:
+			return zero, mismatch()
+		}
+	case *Object:
+		if !actual.IsA(TYPE_OBJECT) {
+			return zero, mismatch()
+		}
+		var obj *Object
+		if c := C.g_value_get_object(v.native()); c != nil {
+			obj = Take(unsafe.Pointer(c))
+		}
+		val = obj
+	case *ParamSpec:
+		if fundamental != TYPE_PARAM {
+			return zero, mismatch()
+		}
+		val, _ = marshalParam(unsafe.Pointer(v.native()))
+	case *Variant:
+		if actual != TYPE_VARIANT {
+			return zero, mismatch()
+		}
+		val = takeVariant(C.g_value_get_variant(v.native()))
+	default:
+		goValue, err := v.GoValue()
+		if err != nil {
+			return zero, err
+		}
+		ret, ok := goValue.(T)
+		if !ok {
+			return zero, fmt.Errorf("%w: cannot get %T from a value of type %s holding %T", ErrWrongValueType, zero, actual.Name(), goValue)
+		}
+		return ret, nil
+	}
+	return val.(T), nil
+}
+
+// ValueSet sets the content of the initialized Value v to val. It checks the type of v first and returns
+// an error wrapping ErrWrongValueType if it cannot hold a T, see ValueGet for the mapping of the types,
+// or if val is out of the range of the C type of v, e.g. an int that does not fit in a TYPE_INT.
+// Any other type is converted with GValue and must be compatible with the type of v.
+func ValueSet[T any](v *Value, val T) error {
+	actual, fundamental, err := v.Type()
+	if err != nil {
+		return err
+	}
+	mismatch := func() error {
+		return fmt.Errorf("%w: cannot set %T on a value of type %s", ErrWrongValueType, val, actual.Name())
+	}
+	outOfRange := func() error {
+		return fmt.Errorf("%w: %v is out of the range of a value of type %s", ErrWrongValueType, val, actual.Name())
+	}
+
+	if gtype, ok := goEnumType(reflect.TypeOf(val)); ok {
+		if actual != gtype {
+			return mismatch()
+		}
+		rv := reflect.ValueOf(val)
+		if fundamental == TYPE_FLAGS {
+			v.SetFlags(uint(rv.Uint()))
+		} else {
+			v.SetEnum(int(rv.Int()))
+		}
+		return nil
+	}
+
+	switch e := any(val).(type) {
+	case bool:
+		if actual != TYPE_BOOLEAN {
+			return mismatch()
+		}
+		v.SetBool(e)
+	case int8:
+		if actual != TYPE_CHAR {
+			return mismatch()
+		}
+		v.SetSChar(e)
+	case uint8:
+		if actual != TYPE_UCHAR {
+			return mismatch()
+		}
+		v.SetUChar(e)
+	case int32:
+		if actual != TYPE_INT {
+			return mismatch()
+		}
+		v.SetInt(int(e))
+	case int:
+		switch {
+		case actual == TYPE_INT, fundamental == TYPE_ENUM:
+			if !fitsInt(int64(e), unsafe.Sizeof(C.gint(0))) {
+				return outOfRange()
+			}
+			if actual == TYPE_INT {
+				v.SetInt(e)
+			} else {
+				v.SetEnum(e)
+			}
+		case actual == TYPE_LONG:
+			if !fitsInt(int64(e), unsafe.Sizeof(C.glong(0))) {
+				return outOfRange()
+			}
+			v.SetLong(e)
+		default:
+			return mismatch()
+		}
+	case int64:
+		switch actual {
+		case TYPE_INT64:
+			v.SetInt64(e)
+		case TYPE_LONG:
+			if !fitsInt(e, unsafe.Sizeof(C.glong(0))) {
+				return outOfRange()
+			}
+			v.SetLong(int(e))
+		default:
+			return mismatch()
+		}
+	case uint32:
+		if actual != TYPE_UINT {
+			return mismatch()
+		}
+		v.SetUInt(uint(e))
+	case uint:
+		switch {
+		case actual == TYPE_UINT, fundamental == TYPE_FLAGS:
+			if !fitsUint(uint64(e), unsafe.Sizeof(C.guint(0))) {
+				return outOfRange()
+			}
+			if actual == TYPE_UINT {
+				v.SetUInt(e)
+			} else {
+				v.SetFlags(e)
+			}
+		case actual == TYPE_ULONG:
+			if !fitsUint(uint64(e), unsafe.Sizeof(C.gulong(0))) {
+				return outOfRange()
+			}
+			v.SetULong(e)
+		default:
+			return mismatch()
+		}
+	case uint64:
+		switch actual {
+		case TYPE_UINT64:
+			v.SetUInt64(e)
+		case TYPE_ULONG:
+			if !fitsUint(e, unsafe.Sizeof(C.gulong(0))) {
+				return outOfRange()
+			}
+			v.SetULong(uint(e))
+		default:
+			return mismatch()
+		}
+	case float32:
+		if actual != TYPE_FLOAT {
+			return mismatch()
+		}
+		v.SetFloat(e)
+	case float64:
+		if actual != TYPE_DOUBLE {
+			return mismatch()
+		}
+		v.SetDouble(e)
+	case string:
+		if actual != TYPE_STRING {
+			return mismatch()
+		}
+		v.SetString(e)
+	case unsafe.Pointer:
+		switch fundamental {
+		case TYPE_POINTER:
+			v.SetPointer(e)
+		case TYPE_BOXED:
+			v.SetBoxed(e)
+		default:
+			return mismatch()
+		}
+	case *Object:
+		if !actual.IsA(TYPE_OBJECT) || (e != nil && !e.IsA(actual)) {
+			return mismatch()
+		}
+		var obj C.gpointer
+		if e != nil {
+			obj = C.gpointer(e.GObject)
+		}
+		C.g_value_set_object(v.native(), obj)
+	case *ParamSpec:
+		if fundamental != TYPE_PARAM || (e != nil && !Type(e.paramSpec.g_type_instance.g_class.g_type).IsA(actual)) {
+			return mismatch()
+		}
+		v.SetParam(e)
+	case *Variant:
+		if actual != TYPE_VARIANT {
+			return mismatch()
+		}
+		v.SetVariant(e)
+	default:
+		gv, err := GValue(val)
+		if err != nil {
+			return err
+		}
+		if valActual, _, _ := gv.Type(); !gobool(C.g_value_type_compatible(C.GType(valActual), C.GType(actual))) {
+			return fmt.Errorf("%w: cannot set %T (%s) on a value of type %s", ErrWrongValueType, val, valActual.Name(), actual.Name())
+		}
+		C.g_value_copy(gv.native(), v.native())
+	}
+	return nil
+}
+
+// fitsInt returns true if val fits in a signed C integer of size bytes.
+func fitsInt(val int64, size uintptr) bool {
+	bits := size * 8
+	return val >= -1<<(bits-1) && val <= 1<<(bits-1)-1
+}
+
+// fitsUint returns true if val fits in an unsigned C integer of size bytes.
+func fitsUint(val uint64, size uintptr) bool {
+	return size >= 8 || val < 1<<(size*8)
+}
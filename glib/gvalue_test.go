@@ -0,0 +1,222 @@
+package glib_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type namedInt16 int16
+type namedInt32 int32
+
+func TestGValueRoundTrip(t *testing.T) {
+	obj, err := glib.NewObjectWithProperties(glib.TYPE_OBJECT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		in    interface{}
+		gtype glib.Type
+		out   interface{}
+	}{
+		{"named int16", namedInt16(-3), glib.TYPE_INT, -3},
+		{"named int32", namedInt32(1 << 20), glib.TYPE_INT, 1 << 20},
+		{"uint16", uint16(7), glib.TYPE_UINT, uint(7)},
+		{"strv", []string{"a", "b"}, glib.TYPE_STRV, []string{"a", "b"}},
+		{"bytes", []byte("foo"), glib.TYPE_BYTE_SLICE, []byte("foo")},
+		{"byte slices", [][]byte{[]byte("foo"), []byte("bar")}, glib.TYPE_BYTE_SLICE_ARRAY, [][]byte{[]byte("foo"), []byte("bar")}},
+		{"empty byte slices", [][]byte{}, glib.TYPE_BYTE_SLICE_ARRAY, [][]byte{}},
+		{"empty objects", []*glib.Object{}, glib.TYPE_OBJECT_ARRAY, []*glib.Object{}},
+		{"vardict", map[string]interface{}{"name": "x", "count": int32(2), "tags": []string{"t"}, "nested": map[string]interface{}{"ok": true}}, glib.TYPE_VARIANT,
+			map[string]interface{}{"name": "x", "count": int32(2), "tags": []string{"t"}, "nested": map[string]interface{}{"ok": true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := glib.GValue(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual, _, _ := v.Type(); actual != tt.gtype {
+				t.Fatalf("expected type %s, got %s", tt.gtype.Name(), actual.Name())
+			}
+			out, err := v.GoValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(out, tt.out) {
+				t.Fatalf("expected %#v, got %#v", tt.out, out)
+			}
+		})
+	}
+
+	// byte slices can be passed where GBytes are expected
+	v, err := glib.GValue([]byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err = v.TransformTo(glib.TypeFromName("GBytes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := v.GoValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes, ok := out.(*glib.Bytes); !ok || string(bytes.Data()) != "foo" {
+		t.Fatalf("expected the bytes to be transformed to *glib.Bytes, got %#v", out)
+	}
+
+	v, err = glib.GValue([]*glib.Object{obj, obj})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = v.GoValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects, ok := out.([]*glib.Object)
+	if !ok || len(objects) != 2 || objects[1].Native() != obj.Native() {
+		t.Fatalf("unexpected objects: %#v", out)
+	}
+}
+
+func TestValueGetSet(t *testing.T) {
+	v, err := glib.ValueInit(glib.TYPE_INT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := glib.ValueSet(v, 42); err != nil {
+		t.Fatal(err)
+	}
+	if i, err := glib.ValueGet[int](v); err != nil || i != 42 {
+		t.Fatalf("expected 42, got %v (%v)", i, err)
+	}
+	if i, err := glib.ValueGet[int32](v); err != nil || i != 42 {
+		t.Fatalf("expected 42, got %v (%v)", i, err)
+	}
+
+	if _, err := glib.ValueGet[string](v); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType, got %v", err)
+	}
+	if err := glib.ValueSet(v, "foo"); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType, got %v", err)
+	}
+	if err := glib.ValueSet(v, int64(1)); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType, got %v", err)
+	}
+	if err := glib.ValueSet(v, math.MaxInt32+1); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType for an int out of range, got %v", err)
+	}
+	if i, err := glib.ValueGet[int](v); err != nil || i != 42 {
+		t.Fatalf("expected the value to be unchanged, got %v (%v)", i, err)
+	}
+
+	u, err := glib.ValueInit(glib.TYPE_UINT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := glib.ValueSet(u, uint(math.MaxUint32)); err != nil {
+		t.Fatal(err)
+	}
+	if err := glib.ValueSet(u, uint(math.MaxUint32+1)); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType for a uint out of range, got %v", err)
+	}
+
+	strv, err := glib.ValueInit(glib.TYPE_STRV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := glib.ValueSet(strv, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := glib.ValueGet[[]string](strv); err != nil || !reflect.DeepEqual(s, []string{"a", "b"}) {
+		t.Fatalf("expected [a b], got %v (%v)", s, err)
+	}
+
+	obj, err := glib.NewObjectWithProperties(glib.TYPE_OBJECT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objValue, err := glib.ValueInit(glib.TYPE_OBJECT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := glib.ValueSet(objValue, obj); err != nil {
+		t.Fatal(err)
+	}
+	if o, err := glib.ValueGet[*glib.Object](objValue); err != nil || o.Native() != obj.Native() {
+		t.Fatalf("expected the object, got %v (%v)", o, err)
+	}
+	if _, err := glib.ValueGet[*glib.Variant](objValue); !errors.Is(err, glib.ErrWrongValueType) {
+		t.Fatalf("expected ErrWrongValueType, got %v", err)
+	}
+}
+
+func TestValueTransform(t *testing.T) {
+	if !glib.ValueTypeTransformable(glib.TYPE_INT, glib.TYPE_STRING) {
+		t.Fatal("expected int to be transformable to string")
+	}
+
+	str, err := glib.GValue("42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := str.TransformTo(glib.TYPE_INT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, err := glib.ValueGet[int](v); err != nil || i != 42 {
+		t.Fatalf("expected 42, got %v (%v)", i, err)
+	}
+
+	back, err := v.TransformTo(glib.TYPE_STRING)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := glib.ValueGet[string](back); err != nil || s != "42" {
+		t.Fatalf("expected \"42\", got %q (%v)", s, err)
+	}
+
+	if _, err := str.TransformTo(glib.TYPE_BOOLEAN); err == nil {
+		t.Fatal("expected an error transforming \"42\" to a boolean")
+	}
+
+	param := glib.NewIntParam("level", "Level", "", 0, 10, 5, glib.ParameterReadWrite)
+	defer param.Unref()
+
+	if min, ok := param.Minimum(); !ok || min != 0 {
+		t.Fatalf("expected minimum 0, got %v", min)
+	}
+	if max, ok := param.Maximum(); !ok || max != 10 {
+		t.Fatalf("expected maximum 10, got %v", max)
+	}
+
+	def, err := param.DefaultValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !param.ValueIsDefault(def) {
+		t.Fatal("expected the default value to be the default")
+	}
+
+	if _, err := param.Convert(str, true); err == nil {
+		t.Fatal("expected strict conversion of an out of range value to fail")
+	}
+	clamped, err := param.Convert(str, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, err := glib.ValueGet[int](clamped); err != nil || i != 10 {
+		t.Fatalf("expected 10, got %v (%v)", i, err)
+	}
+	if param.Validate(clamped) {
+		t.Fatal("expected the clamped value to be valid")
+	}
+}
@@ -10,6 +10,9 @@ import "C"
 import (
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -91,6 +94,17 @@ func takeVariant(p *C.GVariant) *Variant {
 	return obj
 }
 
+// wrapFullVariant wraps a native GVariant that was returned with full ownership transfer,
+// it is only unreffed during GC.
+func wrapFullVariant(p *C.GVariant) *Variant {
+	if p == nil {
+		return nil
+	}
+	obj := newVariant(p)
+	runtime.SetFinalizer(obj, (*Variant).Unref)
+	return obj
+}
+
 // IsFloating returns true if the variant has a floating reference count.
 // Reference counting is usually handled in the gotk layer,
 // most applications should not call this.
@@ -186,6 +200,11 @@ func (v *Variant) TypeString() string {
 	return C.GoString((*C.char)(C.g_variant_get_type_string(v.native())))
 }
 
+// Classify is a wrapper around g_variant_classify().
+func (v *Variant) Classify() VariantClass {
+	return VariantClass(C.g_variant_classify(v.native()))
+}
+
 // IsContainer returns true if the variant is a container and false otherwise.
 func (v *Variant) IsContainer() bool {
 	return gobool(C.g_variant_is_container(v.native()))
@@ -214,15 +233,7 @@ func (v *Variant) GetString() string {
 // GetVariant is a wrapper around g_variant_get_variant.
 // It unboxes a nested GVariant.
 func (v *Variant) GetVariant() *Variant {
-	c := C.g_variant_get_variant(v.native())
-	if c == nil {
-		return nil
-	}
-	// The returned value is returned with full ownership transfer,
-	// only Unref(), don't Ref().
-	obj := newVariant(c)
-	runtime.SetFinalizer(obj, (*Variant).Unref)
-	return obj
+	return wrapFullVariant(C.g_variant_get_variant(v.native()))
 }
 
 // GetStrv returns a slice of strings from this variant.  It wraps
@@ -330,9 +341,138 @@ func (v *Variant) AnnotatedString() string {
 	return C.GoString((*C.char)(gc))
 }
 
+// VariantParseError is returned by VariantParse for invalid text. Start and End are the byte
+// offsets of the invalid part of the text. If two parts conflict, they span both.
+type VariantParseError struct {
+	Start, End int
+	Message    string
+	// Context is the text with the invalid part marked, as formatted by
+	// g_variant_parse_error_print_context().
+	Context string
+}
+
+func (e *VariantParseError) Error() string {
+	return fmt.Sprintf("%d-%d: %s", e.Start, e.End, e.Message)
+}
+
+// newVariantParseError converts a GError from g_variant_parse(). Its message is prefixed with one or
+// two locations of the error, "start[-end][,start[-end]]:".
+func newVariantParseError(gerr *C.GError, text string) *VariantParseError {
+	message := C.GoString((*C.char)(gerr.message))
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+	cctx := C.g_variant_parse_error_print_context(gerr, (*C.gchar)(ctext))
+	defer C.g_free(C.gpointer(cctx))
+
+	e := &VariantParseError{Message: message, Context: C.GoString((*C.char)(cctx))}
+	location, rest, ok := strings.Cut(message, ":")
+	if !ok {
+		return e
+	}
+
+	var offsets []int
+	for _, part := range strings.FieldsFunc(location, func(r rune) bool { return r == ',' || r == '-' }) {
+		offset, err := strconv.Atoi(part)
+		if err != nil {
+			return e
+		}
+		offsets = append(offsets, offset)
+	}
+	if len(offsets) == 0 {
+		return e
+	}
+	e.Start, e.End, e.Message = offsets[0], offsets[len(offsets)-1], rest
+	return e
+}
+
+// VariantParse is a wrapper around g_variant_parse(). It parses the text format written by
+// Variant.String and AnnotatedString. If t is not nil the text is parsed as a value of that type,
+// otherwise the type is inferred. Errors are returned as *VariantParseError.
+func VariantParse(t *VariantType, text string) (*Variant, error) {
+	// the parser does not validate strings
+	if !utf8.ValidString(text) {
+		start := 0
+		for start < len(text) {
+			r, size := utf8.DecodeRuneInString(text[start:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			start += size
+		}
+		return nil, &VariantParseError{
+			Start:   start,
+			End:     start + 1,
+			Message: "invalid UTF-8",
+			Context: fmt.Sprintf("invalid UTF-8 at byte %d of %q", start, text),
+		}
+	}
+
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+	limit := (*C.gchar)(unsafe.Add(unsafe.Pointer(ctext), len(text)))
+
+	var gerr *C.GError
+	c := C.g_variant_parse(t.native(), (*C.gchar)(ctext), limit, nil, &gerr)
+	if c == nil {
+		defer C.g_error_free(gerr)
+		return nil, newVariantParseError(gerr, text)
+	}
+	return wrapFullVariant(c), nil
+}
+
+// Equal is a wrapper around g_variant_equal(). It returns true if both variants have the same
+// type and value.
+func (v *Variant) Equal(other *Variant) bool {
+	return gobool(C.g_variant_equal(C.gconstpointer(v.native()), C.gconstpointer(other.native())))
+}
+
+// Size is a wrapper around g_variant_get_size(). It returns the size of the serialized data.
+func (v *Variant) Size() uint {
+	return uint(C.g_variant_get_size(v.native()))
+}
+
+// DataAsBytes is a wrapper around g_variant_get_data_as_bytes(). It returns the serialized data of
+// the variant in the byte order of the machine. Use VariantFromBytes with the same type to load it.
+func (v *Variant) DataAsBytes() *Bytes {
+	return wrapBytes(C.g_variant_get_data_as_bytes(v.native()))
+}
+
+// Data returns a copy of the serialized data of the variant, see DataAsBytes.
+func (v *Variant) Data() []byte {
+	return v.DataAsBytes().Data()
+}
+
+// VariantFromBytes is a wrapper around g_variant_new_from_bytes(). It creates a variant of type t from
+// serialized data. If trusted is false the data is checked and invalid parts are replaced by default
+// values when accessed, so untrusted data can be loaded safely.
+func VariantFromBytes(t *VariantType, bytes *Bytes, trusted bool) *Variant {
+	return takeVariant(C.g_variant_new_from_bytes(t.native(), bytes.ptr, gbool(trusted)))
+}
+
+// VariantFromData creates a variant of type t from a copy of data, see VariantFromBytes.
+func VariantFromData(t *VariantType, data []byte, trusted bool) *Variant {
+	return VariantFromBytes(t, NewBytes(data), trusted)
+}
+
+// Byteswap is a wrapper around g_variant_byteswap(). It returns a copy of the variant with the byte
+// order of all numbers swapped, to load data serialized on a machine of the other endianness.
+func (v *Variant) Byteswap() *Variant {
+	return wrapFullVariant(C.g_variant_byteswap(v.native()))
+}
+
+// NormalForm is a wrapper around g_variant_get_normal_form(). It returns the variant in normal form,
+// in which any invalid serialized data is replaced by default values.
+func (v *Variant) NormalForm() *Variant {
+	return wrapFullVariant(C.g_variant_get_normal_form(v.native()))
+}
+
+// IsNormalForm is a wrapper around g_variant_is_normal_form().
+func (v *Variant) IsNormalForm() bool {
+	return gobool(C.g_variant_is_normal_form(v.native()))
+}
+
 // TODO:
 //gint	g_variant_compare ()
-//GVariantClass	g_variant_classify ()
 //gboolean	g_variant_check_format_string ()
 //void	g_variant_get ()
 //void	g_variant_get_va ()
@@ -368,58 +508,28 @@ func (v *Variant) AnnotatedString() string {
 //GVariant *	g_variant_new_dict_entry ()
 //GVariant *	g_variant_new_fixed_array ()
 //GVariant *	g_variant_get_maybe ()
-//gsize	g_variant_n_children ()
-//GVariant *	g_variant_get_child_value ()
 //void	g_variant_get_child ()
-//GVariant *	g_variant_lookup_value ()
 //gboolean	g_variant_lookup ()
 //gconstpointer	g_variant_get_fixed_array ()
-//gsize	g_variant_get_size ()
 //gconstpointer	g_variant_get_data ()
-//GBytes *	g_variant_get_data_as_bytes ()
 //void	g_variant_store ()
 //GVariant *	g_variant_new_from_data ()
-//GVariant *	g_variant_new_from_bytes ()
-//GVariant *	g_variant_byteswap ()
-//GVariant *	g_variant_get_normal_form ()
-//gboolean	g_variant_is_normal_form ()
 //guint	g_variant_hash ()
-//gboolean	g_variant_equal ()
 //gchar *	g_variant_print ()
 //GString *	g_variant_print_string ()
 //GVariantIter *	g_variant_iter_copy ()
-//void	g_variant_iter_free ()
 //gsize	g_variant_iter_init ()
-//gsize	g_variant_iter_n_children ()
-//GVariantIter *	g_variant_iter_new ()
-//GVariant *	g_variant_iter_next_value ()
 //gboolean	g_variant_iter_next ()
 //gboolean	g_variant_iter_loop ()
-//void	g_variant_builder_unref ()
 //GVariantBuilder *	g_variant_builder_ref ()
-//GVariantBuilder *	g_variant_builder_new ()
 //void	g_variant_builder_init ()
 //void	g_variant_builder_clear ()
-//void	g_variant_builder_add_value ()
 //void	g_variant_builder_add ()
 //void	g_variant_builder_add_parsed ()
-//GVariant *	g_variant_builder_end ()
-//void	g_variant_builder_open ()
-//void	g_variant_builder_close ()
-//void	g_variant_dict_unref ()
 //GVariantDict *	g_variant_dict_ref ()
-//GVariantDict *	g_variant_dict_new ()
 //void	g_variant_dict_init ()
 //void	g_variant_dict_clear ()
-//gboolean	g_variant_dict_contains ()
 //gboolean	g_variant_dict_lookup ()
-//GVariant *	g_variant_dict_lookup_value ()
 //void	g_variant_dict_insert ()
-//void	g_variant_dict_insert_value ()
-//gboolean	g_variant_dict_remove ()
-//GVariant *	g_variant_dict_end ()
-//#define	G_VARIANT_PARSE_ERROR
-//GVariant *	g_variant_parse ()
 //GVariant *	g_variant_new_parsed_va ()
 //GVariant *	g_variant_new_parsed ()
-//gchar *	g_variant_parse_error_print_context ()
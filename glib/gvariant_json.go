@@ -0,0 +1,386 @@
+package glib
+
+// #include "glib.go.h"
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// maxSafeJSONInteger is the largest integer that is exact as a JSON number in most implementations,
+// as they use doubles.
+const maxSafeJSONInteger = 1<<53 - 1
+
+// VariantFromJSON converts a JSON value to a variant of type t:
+//
+//	b                  true or false
+//	y, n, q, i, u, h   number
+//	x, t               number, or a string holding the number for values that do not fit a double
+//	d                  number
+//	s, o, g            string
+//	v                  any value, converted as if t was nil
+//	m*                 null for Nothing, otherwise the value
+//	ay                 base64 string or array of numbers
+//	a{**}              object, non-string keys are parsed from the member names
+//	other arrays       array
+//	tuples             array with one element per tuple member
+//	dict entries       array of key and value
+//
+// If t is nil the type is inferred: objects are converted to a{sv}, arrays to av, integers to x (or
+// t if they only fit an unsigned integer), other numbers to d and null to a Nothing of type mv.
+func VariantFromJSON(data []byte, t *VariantType) (*Variant, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid JSON: data after the top-level value")
+	}
+
+	var c *C.GVariant
+	var err error
+	if t == nil {
+		c, err = jsonToUntypedVariant(value)
+	} else {
+		typeString := t.String()
+		if !isDefiniteVariantTypeString(typeString) {
+			return nil, fmt.Errorf("cannot convert JSON to the indefinite type %s", typeString)
+		}
+		c, err = jsonToVariant(value, typeString)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return takeVariant(c), nil
+}
+
+// MarshalJSON implements json.Marshaler, see VariantFromJSON for the format. Values of type x and t
+// that do not fit a double are written as strings, ay as base64 strings and the type of values boxed
+// in v is lost.
+func (v *Variant) MarshalJSON() ([]byte, error) {
+	value, err := variantToJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// jsonToUntypedVariant converts a decoded JSON value to a floating variant of inferred type.
+func jsonToUntypedVariant(value interface{}) (*C.GVariant, error) {
+	switch v := value.(type) {
+	case nil:
+		return C.g_variant_new_maybe(C.G_VARIANT_TYPE_VARIANT, nil), nil
+	case bool:
+		return C.g_variant_new_boolean(gbool(v)), nil
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return C.g_variant_new_int64(C.gint64(i)), nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return C.g_variant_new_uint64(C.guint64(u)), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_double(C.gdouble(f)), nil
+	case string:
+		return marshalVariantValue(reflect.ValueOf(v), "s")
+	case []interface{}:
+		ctype := newCVariantType("av")
+		defer C.g_variant_type_free(ctype)
+
+		var builder C.GVariantBuilder
+		C.g_variant_builder_init(&builder, ctype)
+		for i, elem := range v {
+			child, err := jsonToUntypedVariant(elem)
+			if err != nil {
+				C.g_variant_builder_clear(&builder)
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			C.g_variant_builder_add_value(&builder, C.g_variant_new_variant(child))
+		}
+		return C.g_variant_builder_end(&builder), nil
+	case map[string]interface{}:
+		var builder C.GVariantBuilder
+		C.g_variant_builder_init(&builder, C.G_VARIANT_TYPE_VARDICT)
+		for _, key := range sortedJSONKeys(v) {
+			child, err := jsonToUntypedVariant(v[key])
+			if err != nil {
+				C.g_variant_builder_clear(&builder)
+				return nil, fmt.Errorf("member %s: %w", key, err)
+			}
+			ckey, err := marshalVariantValue(reflect.ValueOf(key), "s")
+			if err != nil {
+				sinkAndUnref([]*C.GVariant{child})
+				C.g_variant_builder_clear(&builder)
+				return nil, err
+			}
+			C.g_variant_builder_add_value(&builder, C.g_variant_new_dict_entry(ckey, C.g_variant_new_variant(child)))
+		}
+		return C.g_variant_builder_end(&builder), nil
+	}
+	return nil, fmt.Errorf("unexpected JSON value %T", value)
+}
+
+func sortedJSONKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonMismatch(value interface{}, typeString string) error {
+	return fmt.Errorf("cannot convert JSON %T to %s: %w", value, typeString, ErrVariantTypeMismatch)
+}
+
+// jsonToVariant converts a decoded JSON value to a floating variant of the given type.
+func jsonToVariant(value interface{}, typeString string) (*C.GVariant, error) {
+	switch typeString[0] {
+	case 'b':
+		b, ok := value.(bool)
+		if !ok {
+			return nil, jsonMismatch(value, typeString)
+		}
+		return C.g_variant_new_boolean(gbool(b)), nil
+
+	case 'y', 'n', 'q', 'i', 'u', 'x', 't', 'h':
+		var s string
+		switch v := value.(type) {
+		case json.Number:
+			s = string(v)
+		case string:
+			s = v
+		default:
+			return nil, jsonMismatch(value, typeString)
+		}
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return marshalVariantInteger(reflect.ValueOf(i), typeString)
+		}
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not an integer: %w", s, ErrVariantTypeMismatch)
+		}
+		return marshalVariantInteger(reflect.ValueOf(u), typeString)
+
+	case 'd':
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, jsonMismatch(value, typeString)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_double(C.gdouble(f)), nil
+
+	case 's', 'o', 'g':
+		s, ok := value.(string)
+		if !ok {
+			return nil, jsonMismatch(value, typeString)
+		}
+		return marshalVariantValue(reflect.ValueOf(s), typeString)
+
+	case 'v':
+		child, err := jsonToUntypedVariant(value)
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_variant(child), nil
+
+	case 'm':
+		if value == nil {
+			ctype := newCVariantType(typeString[1:])
+			defer C.g_variant_type_free(ctype)
+			return C.g_variant_new_maybe(ctype, nil), nil
+		}
+		child, err := jsonToVariant(value, typeString[1:])
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_maybe(nil, child), nil
+
+	case 'a':
+		if s, ok := value.(string); ok && typeString == "ay" {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+			return marshalVariantArray(reflect.ValueOf(data), typeString)
+		}
+		if m, ok := value.(map[string]interface{}); ok && typeString[1] == '{' {
+			return jsonObjectToVariant(m, typeString)
+		}
+
+		elems, ok := value.([]interface{})
+		if !ok {
+			return nil, jsonMismatch(value, typeString)
+		}
+		ctype := newCVariantType(typeString)
+		defer C.g_variant_type_free(ctype)
+
+		var builder C.GVariantBuilder
+		C.g_variant_builder_init(&builder, ctype)
+		for i, elem := range elems {
+			child, err := jsonToVariant(elem, typeString[1:])
+			if err != nil {
+				C.g_variant_builder_clear(&builder)
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			C.g_variant_builder_add_value(&builder, child)
+		}
+		return C.g_variant_builder_end(&builder), nil
+
+	case '(', '{':
+		elems, ok := value.([]interface{})
+		if !ok {
+			return nil, jsonMismatch(value, typeString)
+		}
+		childTypes := childVariantTypeStrings(typeString)
+		if len(elems) != len(childTypes) {
+			return nil, fmt.Errorf("cannot convert a JSON array of %d elements to %s: %w", len(elems), typeString, ErrVariantTypeMismatch)
+		}
+
+		children := make([]*C.GVariant, 0, len(elems))
+		for i, elem := range elems {
+			child, err := jsonToVariant(elem, childTypes[i])
+			if err != nil {
+				sinkAndUnref(children)
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			children = append(children, child)
+		}
+		if typeString[0] == '{' {
+			return C.g_variant_new_dict_entry(children[0], children[1]), nil
+		}
+		return C.g_variant_new_tuple(unsafe.SliceData(children), C.gsize(len(children))), nil
+	}
+	return nil, jsonMismatch(value, typeString)
+}
+
+// jsonObjectToVariant converts a JSON object to a dictionary of the given type, parsing the keys
+// from the member names.
+func jsonObjectToVariant(m map[string]interface{}, typeString string) (*C.GVariant, error) {
+	entryTypes := childVariantTypeStrings(typeString[1:])
+
+	ctype := newCVariantType(typeString)
+	defer C.g_variant_type_free(ctype)
+
+	var builder C.GVariantBuilder
+	C.g_variant_builder_init(&builder, ctype)
+	for _, key := range sortedJSONKeys(m) {
+		var keyValue interface{} = key
+		switch entryTypes[0] {
+		case "b":
+			b, err := strconv.ParseBool(key)
+			if err != nil {
+				C.g_variant_builder_clear(&builder)
+				return nil, fmt.Errorf("member %s: %w", key, err)
+			}
+			keyValue = b
+		case "d", "y", "n", "q", "i", "u", "x", "t", "h":
+			keyValue = json.Number(key)
+		}
+
+		ckey, err := jsonToVariant(keyValue, entryTypes[0])
+		if err != nil {
+			C.g_variant_builder_clear(&builder)
+			return nil, fmt.Errorf("member %s: %w", key, err)
+		}
+		cvalue, err := jsonToVariant(m[key], entryTypes[1])
+		if err != nil {
+			sinkAndUnref([]*C.GVariant{ckey})
+			C.g_variant_builder_clear(&builder)
+			return nil, fmt.Errorf("member %s: %w", key, err)
+		}
+		C.g_variant_builder_add_value(&builder, C.g_variant_new_dict_entry(ckey, cvalue))
+	}
+	return C.g_variant_builder_end(&builder), nil
+}
+
+// variantToJSON converts v to a value that encodes to JSON as described in MarshalJSON.
+func variantToJSON(v *Variant) (interface{}, error) {
+	switch v.Classify() {
+	case VARIANT_CLASS_BOOLEAN:
+		return v.GetBoolean(), nil
+	case VARIANT_CLASS_BYTE, VARIANT_CLASS_UINT16, VARIANT_CLASS_UINT32:
+		return v.GetUint()
+	case VARIANT_CLASS_INT16, VARIANT_CLASS_INT32:
+		return v.GetInt()
+	case VARIANT_CLASS_HANDLE:
+		return int32(C.g_variant_get_handle(v.native())), nil
+	case VARIANT_CLASS_INT64:
+		i, _ := v.GetInt()
+		if i > maxSafeJSONInteger || i < -maxSafeJSONInteger {
+			return strconv.FormatInt(i, 10), nil
+		}
+		return i, nil
+	case VARIANT_CLASS_UINT64:
+		u, _ := v.GetUint()
+		if u > maxSafeJSONInteger {
+			return strconv.FormatUint(u, 10), nil
+		}
+		return u, nil
+	case VARIANT_CLASS_DOUBLE:
+		f := float64(C.g_variant_get_double(v.native()))
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%v cannot be represented in JSON", f)
+		}
+		return f, nil
+	case VARIANT_CLASS_STRING, VARIANT_CLASS_OBJECT_PATH, VARIANT_CLASS_SIGNATURE:
+		return v.GetString(), nil
+	case VARIANT_CLASS_VARIANT:
+		return variantToJSON(v.GetVariant())
+	case VARIANT_CLASS_MAYBE:
+		if v.NChildren() == 0 {
+			return nil, nil
+		}
+		return variantToJSON(v.ChildValue(0))
+	case VARIANT_CLASS_ARRAY:
+		typeString := v.TypeString()
+		if typeString == "ay" {
+			return v.Data(), nil
+		}
+		if strings.HasPrefix(typeString, "a{") {
+			out := make(map[string]interface{}, v.NChildren())
+			for key, value := range v.Entries() {
+				k, err := variantToJSON(key)
+				if err != nil {
+					return nil, err
+				}
+				out[fmt.Sprint(k)], err = variantToJSON(value)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		}
+	}
+
+	// arrays, tuples and dict entries
+	out := make([]interface{}, 0, v.NChildren())
+	for _, child := range v.Children() {
+		value, err := variantToJSON(child)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
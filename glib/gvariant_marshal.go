@@ -0,0 +1,795 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+static GVariant * _g_variant_new_fixed_bytes (gconstpointer data, gsize n)
+{
+	return g_variant_new_fixed_array(G_VARIANT_TYPE_BYTE, data, n, 1);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ErrVariantTypeMismatch is returned when a go value cannot be converted to or from a variant of a given type.
+var ErrVariantTypeMismatch = errors.New("variant type mismatch")
+
+var variantPtrType = reflect.TypeOf((*Variant)(nil))
+
+// MarshalVariant converts a go value to a variant. The variant type is inferred from the go type:
+//
+//	bool                  b
+//	int8, int16           n
+//	int32                 i
+//	int, int64            x
+//	uint8                 y
+//	uint16                q
+//	uint32                u
+//	uint, uint64, uintptr t
+//	float32, float64      d
+//	string                s
+//	*Variant, interface   v
+//	pointer               m (maybe), nil pointers are Nothing
+//	slice, array          a
+//	map                   a{} (dictionary), the key must be a basic type
+//	struct                () (tuple) of the exported fields
+//
+// Struct fields can be tagged with `variant:"-"` to be skipped, or with a type string to use instead
+// of the inferred one, e.g. `variant:"o"` for a string holding an object path or `variant:"h"` for a
+// handle. Dictionaries are sorted by key.
+func MarshalVariant(v interface{}) (*Variant, error) {
+	if variant, ok := v.(*Variant); ok {
+		return variant, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("cannot marshal nil to a variant: %w", ErrVariantTypeMismatch)
+	}
+	typeString, err := variantTypeStringOf(rv.Type(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c, err := marshalVariantValue(rv, typeString)
+	if err != nil {
+		return nil, err
+	}
+	return takeVariant(c), nil
+}
+
+// VariantTypeStringOf returns the variant type string MarshalVariant uses for values of the type of v.
+func VariantTypeStringOf(v interface{}) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("cannot infer the variant type of nil: %w", ErrVariantTypeMismatch)
+	}
+	return variantTypeStringOf(reflect.TypeOf(v), nil)
+}
+
+// UnmarshalVariant stores the content of variant in the value pointed to by out, see MarshalVariant
+// for the mapping of types. Integers are converted between sizes if they fit, variants (v) are unboxed
+// as needed and empty interfaces receive the values returned by Variant.GoValue.
+func UnmarshalVariant(variant *Variant, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cannot unmarshal into %T, expected a non-nil pointer", out)
+	}
+	if variant.native() == nil {
+		return errors.New("cannot unmarshal a nil variant")
+	}
+	return unmarshalVariantValue(variant.native(), rv.Elem())
+}
+
+// GoValue converts the variant to a go value. Basic types, as, ay and a{sv} are returned as the
+// corresponding go types, v is unboxed and other variants are returned as *Variant.
+func (v *Variant) GoValue() interface{} {
+	return variantToGo(v)
+}
+
+// variantField is an exported struct field that is part of the tuple of its struct.
+type variantField struct {
+	index      int
+	typeString string
+}
+
+// variantFields returns the fields of struct type t that are marshaled to a tuple.
+func variantFields(t reflect.Type, visiting map[reflect.Type]bool) ([]variantField, error) {
+	var fields []variantField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("variant")
+		if tag == "-" {
+			continue
+		}
+		if tag != "" {
+			if !isDefiniteVariantTypeString(tag) {
+				return nil, fmt.Errorf("field %s: invalid variant type %q", f.Name, tag)
+			}
+			fields = append(fields, variantField{index: i, typeString: tag})
+			continue
+		}
+
+		typeString, err := variantTypeStringOf(f.Type, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		fields = append(fields, variantField{index: i, typeString: typeString})
+	}
+	return fields, nil
+}
+
+// variantTypeStringOf infers the variant type of values of type t. visiting contains the struct
+// types currently inferred, to reject recursive types.
+func variantTypeStringOf(t reflect.Type, visiting map[reflect.Type]bool) (string, error) {
+	if t == variantPtrType {
+		return "v", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "b", nil
+	case reflect.Int8, reflect.Int16:
+		return "n", nil
+	case reflect.Int32:
+		return "i", nil
+	case reflect.Int, reflect.Int64:
+		return "x", nil
+	case reflect.Uint8:
+		return "y", nil
+	case reflect.Uint16:
+		return "q", nil
+	case reflect.Uint32:
+		return "u", nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return "t", nil
+	case reflect.Float32, reflect.Float64:
+		return "d", nil
+	case reflect.String:
+		return "s", nil
+	case reflect.Interface:
+		return "v", nil
+	case reflect.Ptr:
+		elem, err := variantTypeStringOf(t.Elem(), visiting)
+		if err != nil {
+			return "", err
+		}
+		return "m" + elem, nil
+	case reflect.Slice, reflect.Array:
+		elem, err := variantTypeStringOf(t.Elem(), visiting)
+		if err != nil {
+			return "", err
+		}
+		return "a" + elem, nil
+	case reflect.Map:
+		key, err := variantTypeStringOf(t.Key(), visiting)
+		if err != nil {
+			return "", err
+		}
+		if len(key) != 1 || key == "v" {
+			return "", fmt.Errorf("map key %s is not a basic type: %w", t.Key(), ErrVariantTypeMismatch)
+		}
+		value, err := variantTypeStringOf(t.Elem(), visiting)
+		if err != nil {
+			return "", err
+		}
+		return "a{" + key + value + "}", nil
+	case reflect.Struct:
+		if visiting[t] {
+			return "", fmt.Errorf("recursive type %s: %w", t, ErrVariantTypeMismatch)
+		}
+		if visiting == nil {
+			visiting = make(map[reflect.Type]bool)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		fields, err := variantFields(t, visiting)
+		if err != nil {
+			return "", err
+		}
+		var sb strings.Builder
+		sb.WriteByte('(')
+		for _, f := range fields {
+			sb.WriteString(f.typeString)
+		}
+		sb.WriteByte(')')
+		return sb.String(), nil
+	}
+	return "", fmt.Errorf("cannot convert %s to a variant: %w", t, ErrVariantTypeMismatch)
+}
+
+// isDefiniteVariantTypeString returns true if s is a single complete type without indefinite parts.
+func isDefiniteVariantTypeString(s string) bool {
+	first, rest, ok := splitVariantTypeString(s)
+	return ok && rest == "" && !strings.ContainsAny(first, "*?r")
+}
+
+// splitVariantTypeString splits the first complete type off the type string s.
+func splitVariantTypeString(s string) (first, rest string, ok bool) {
+	if s == "" {
+		return "", "", false
+	}
+
+	switch s[0] {
+	case 'b', 'y', 'n', 'q', 'i', 'u', 'x', 't', 'h', 'd', 's', 'o', 'g', 'v', '*', '?', 'r':
+		return s[:1], s[1:], true
+	case 'a', 'm':
+		elem, rest, ok := splitVariantTypeString(s[1:])
+		return s[:1] + elem, rest, ok
+	case '(':
+		n := 1
+		for {
+			if n >= len(s) {
+				return "", "", false
+			}
+			if s[n] == ')' {
+				return s[:n+1], s[n+1:], true
+			}
+			elem, _, ok := splitVariantTypeString(s[n:])
+			if !ok {
+				return "", "", false
+			}
+			n += len(elem)
+		}
+	case '{':
+		if len(s) < 2 || !strings.ContainsRune("bynqiuxthdsog?", rune(s[1])) {
+			return "", "", false
+		}
+		value, rest, ok := splitVariantTypeString(s[2:])
+		if !ok || rest == "" || rest[0] != '}' {
+			return "", "", false
+		}
+		return s[:3+len(value)], rest[1:], true
+	}
+	return "", "", false
+}
+
+// childVariantTypeStrings returns the types of the elements of a tuple or dictionary entry type.
+func childVariantTypeStrings(typeString string) []string {
+	var children []string
+	s := typeString[1 : len(typeString)-1]
+	for s != "" {
+		var child string
+		child, s, _ = splitVariantTypeString(s)
+		children = append(children, child)
+	}
+	return children
+}
+
+// newCVariantType creates a GVariantType that must be freed with g_variant_type_free.
+func newCVariantType(typeString string) *C.GVariantType {
+	cstr := C.CString(typeString)
+	defer C.free(unsafe.Pointer(cstr))
+	return C.g_variant_type_new((*C.gchar)(cstr))
+}
+
+// sinkAndUnref frees floating variants that were created but not consumed.
+func sinkAndUnref(variants []*C.GVariant) {
+	for _, v := range variants {
+		C.g_variant_unref(C.g_variant_ref_sink(v))
+	}
+}
+
+func variantMismatch(rv reflect.Value, typeString string) error {
+	return fmt.Errorf("cannot marshal %s as %s: %w", rv.Type(), typeString, ErrVariantTypeMismatch)
+}
+
+// marshalVariantValue converts rv to a floating variant of the given type. A *Variant in rv is
+// returned as is, without a reference of its own, callers sink the result either way.
+func marshalVariantValue(rv reflect.Value, typeString string) (*C.GVariant, error) {
+	if rv.Type() == variantPtrType {
+		variant := rv.Interface().(*Variant)
+		if variant.native() == nil {
+			return nil, fmt.Errorf("cannot marshal a nil variant: %w", ErrVariantTypeMismatch)
+		}
+		if typeString == "v" {
+			return C.g_variant_new_variant(variant.native()), nil
+		}
+		if variant.TypeString() != typeString {
+			return nil, fmt.Errorf("cannot marshal a variant of type %s as %s: %w", variant.TypeString(), typeString, ErrVariantTypeMismatch)
+		}
+		return variant.native(), nil
+	}
+
+	if rv.Kind() == reflect.Interface && typeString != "v" {
+		if rv.IsNil() {
+			return nil, variantMismatch(rv, typeString)
+		}
+		return marshalVariantValue(rv.Elem(), typeString)
+	}
+
+	switch typeString[0] {
+	case 'b':
+		if rv.Kind() != reflect.Bool {
+			return nil, variantMismatch(rv, typeString)
+		}
+		return C.g_variant_new_boolean(gbool(rv.Bool())), nil
+
+	case 'y', 'n', 'q', 'i', 'u', 'x', 't', 'h':
+		return marshalVariantInteger(rv, typeString)
+
+	case 'd':
+		if rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+			return nil, variantMismatch(rv, typeString)
+		}
+		return C.g_variant_new_double(C.gdouble(rv.Float())), nil
+
+	case 's', 'o', 'g':
+		if rv.Kind() != reflect.String {
+			return nil, variantMismatch(rv, typeString)
+		}
+		s := rv.String()
+		if !utf8.ValidString(s) || strings.IndexByte(s, 0) >= 0 {
+			return nil, fmt.Errorf("string %q is not valid UTF-8 without NUL bytes", s)
+		}
+
+		cstr := C.CString(s)
+		defer C.free(unsafe.Pointer(cstr))
+		switch typeString {
+		case "o":
+			if !gobool(C.g_variant_is_object_path((*C.gchar)(cstr))) {
+				return nil, fmt.Errorf("%q is not a valid object path", s)
+			}
+			return C.g_variant_new_object_path((*C.gchar)(cstr)), nil
+		case "g":
+			if !gobool(C.g_variant_is_signature((*C.gchar)(cstr))) {
+				return nil, fmt.Errorf("%q is not a valid signature", s)
+			}
+			return C.g_variant_new_signature((*C.gchar)(cstr)), nil
+		}
+		return C.g_variant_new_string((*C.gchar)(cstr)), nil
+
+	case 'v':
+		if rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return nil, fmt.Errorf("cannot marshal a nil interface: %w", ErrVariantTypeMismatch)
+			}
+			rv = rv.Elem()
+		}
+		inner, err := variantTypeStringOf(rv.Type(), nil)
+		if err != nil {
+			return nil, err
+		}
+		child, err := marshalVariantValue(rv, inner)
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_variant(child), nil
+
+	case 'm':
+		elemType := typeString[1:]
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				ctype := newCVariantType(elemType)
+				defer C.g_variant_type_free(ctype)
+				return C.g_variant_new_maybe(ctype, nil), nil
+			}
+			rv = rv.Elem()
+		}
+		child, err := marshalVariantValue(rv, elemType)
+		if err != nil {
+			return nil, err
+		}
+		return C.g_variant_new_maybe(nil, child), nil
+
+	case 'a':
+		if typeString[1] == '{' {
+			return marshalVariantDict(rv, typeString)
+		}
+		return marshalVariantArray(rv, typeString)
+
+	case '(', '{':
+		if rv.Kind() != reflect.Struct {
+			return nil, variantMismatch(rv, typeString)
+		}
+		fields, err := variantFields(rv.Type(), nil)
+		if err != nil {
+			return nil, err
+		}
+		childTypes := childVariantTypeStrings(typeString)
+		if len(fields) != len(childTypes) {
+			return nil, fmt.Errorf("%s has %d fields, but %s has %d: %w", rv.Type(), len(fields), typeString, len(childTypes), ErrVariantTypeMismatch)
+		}
+
+		children := make([]*C.GVariant, 0, len(fields))
+		for i, f := range fields {
+			child, err := marshalVariantValue(rv.Field(f.index), childTypes[i])
+			if err != nil {
+				sinkAndUnref(children)
+				return nil, fmt.Errorf("field %s: %w", rv.Type().Field(f.index).Name, err)
+			}
+			children = append(children, child)
+		}
+
+		if typeString[0] == '{' {
+			return C.g_variant_new_dict_entry(children[0], children[1]), nil
+		}
+		return C.g_variant_new_tuple(unsafe.SliceData(children), C.gsize(len(children))), nil
+	}
+
+	return nil, fmt.Errorf("cannot marshal to a variant of type %s: %w", typeString, ErrVariantTypeMismatch)
+}
+
+// marshalVariantInteger converts an integer to a variant of one of the integer types, failing if
+// the value does not fit.
+func marshalVariantInteger(rv reflect.Value, typeString string) (*C.GVariant, error) {
+	var i int64
+	var u uint64
+	signed := false
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, signed = rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u = rv.Uint()
+	default:
+		return nil, variantMismatch(rv, typeString)
+	}
+
+	fits := func(min int64, max uint64) bool {
+		if signed {
+			return i >= min && (i < 0 || uint64(i) <= max)
+		}
+		return u <= max
+	}
+	if !signed {
+		i = int64(u) // only used if u fits the type, which is checked below
+	}
+	overflow := fmt.Errorf("%v overflows %s: %w", rv.Interface(), typeString, ErrVariantTypeMismatch)
+
+	switch typeString {
+	case "y":
+		if !fits(0, math.MaxUint8) {
+			return nil, overflow
+		}
+		return C.g_variant_new_byte(C.guint8(i)), nil
+	case "q":
+		if !fits(0, math.MaxUint16) {
+			return nil, overflow
+		}
+		return C.g_variant_new_uint16(C.guint16(i)), nil
+	case "u":
+		if !fits(0, math.MaxUint32) {
+			return nil, overflow
+		}
+		return C.g_variant_new_uint32(C.guint32(i)), nil
+	case "t":
+		if !fits(0, math.MaxUint64) {
+			return nil, overflow
+		}
+		if signed {
+			u = uint64(i)
+		}
+		return C.g_variant_new_uint64(C.guint64(u)), nil
+	case "n":
+		if !fits(math.MinInt16, math.MaxInt16) {
+			return nil, overflow
+		}
+		return C.g_variant_new_int16(C.gint16(i)), nil
+	case "i":
+		if !fits(math.MinInt32, math.MaxInt32) {
+			return nil, overflow
+		}
+		return C.g_variant_new_int32(C.gint32(i)), nil
+	case "h":
+		if !fits(math.MinInt32, math.MaxInt32) {
+			return nil, overflow
+		}
+		return C.g_variant_new_handle(C.gint32(i)), nil
+	default: // "x"
+		if !fits(math.MinInt64, math.MaxInt64) {
+			return nil, overflow
+		}
+		return C.g_variant_new_int64(C.gint64(i)), nil
+	}
+}
+
+// marshalVariantArray converts a slice or array to a variant array.
+func marshalVariantArray(rv reflect.Value, typeString string) (*C.GVariant, error) {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, variantMismatch(rv, typeString)
+	}
+
+	if typeString == "ay" && rv.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(data), rv)
+		return C._g_variant_new_fixed_bytes(C.gconstpointer(unsafe.SliceData(data)), C.gsize(len(data))), nil
+	}
+
+	ctype := newCVariantType(typeString)
+	defer C.g_variant_type_free(ctype)
+
+	var builder C.GVariantBuilder
+	C.g_variant_builder_init(&builder, ctype)
+	for i := 0; i < rv.Len(); i++ {
+		child, err := marshalVariantValue(rv.Index(i), typeString[1:])
+		if err != nil {
+			C.g_variant_builder_clear(&builder)
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		C.g_variant_builder_add_value(&builder, child)
+	}
+	return C.g_variant_builder_end(&builder), nil
+}
+
+// marshalVariantDict converts a map to a variant dictionary sorted by key.
+func marshalVariantDict(rv reflect.Value, typeString string) (*C.GVariant, error) {
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		// a slice of entry structs
+		return marshalVariantArray(rv, typeString)
+	}
+	if rv.Kind() != reflect.Map {
+		return nil, variantMismatch(rv, typeString)
+	}
+
+	entryTypes := childVariantTypeStrings(typeString[1:])
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return lessMapKey(keys[i], keys[j]) })
+
+	ctype := newCVariantType(typeString)
+	defer C.g_variant_type_free(ctype)
+
+	var builder C.GVariantBuilder
+	C.g_variant_builder_init(&builder, ctype)
+	for _, key := range keys {
+		ckey, err := marshalVariantValue(key, entryTypes[0])
+		if err != nil {
+			C.g_variant_builder_clear(&builder)
+			return nil, fmt.Errorf("key %v: %w", key, err)
+		}
+		cvalue, err := marshalVariantValue(rv.MapIndex(key), entryTypes[1])
+		if err != nil {
+			sinkAndUnref([]*C.GVariant{ckey})
+			C.g_variant_builder_clear(&builder)
+			return nil, fmt.Errorf("key %v: %w", key, err)
+		}
+		C.g_variant_builder_add_value(&builder, C.g_variant_new_dict_entry(ckey, cvalue))
+	}
+	return C.g_variant_builder_end(&builder), nil
+}
+
+// lessMapKey orders map keys of basic kinds.
+func lessMapKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return false
+}
+
+func variantUnmarshalMismatch(v *C.GVariant, rv reflect.Value) error {
+	typeString := C.GoString((*C.char)(C.g_variant_get_type_string(v)))
+	return fmt.Errorf("cannot unmarshal a variant of type %s into %s: %w", typeString, rv.Type(), ErrVariantTypeMismatch)
+}
+
+// unmarshalVariantValue stores v in the settable rv.
+func unmarshalVariantValue(v *C.GVariant, rv reflect.Value) error {
+	typeString := C.GoString((*C.char)(C.g_variant_get_type_string(v)))
+
+	if rv.Type() == variantPtrType {
+		if typeString == "v" {
+			rv.Set(reflect.ValueOf(newVariant(v).GetVariant()))
+		} else {
+			rv.Set(reflect.ValueOf(takeVariant(v)))
+		}
+		return nil
+	}
+
+	if rv.Kind() == reflect.Interface {
+		value := reflect.ValueOf(variantToGo(takeVariant(v)))
+		if !value.Type().AssignableTo(rv.Type()) {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		rv.Set(value)
+		return nil
+	}
+
+	if typeString == "v" {
+		inner := C.g_variant_get_variant(v)
+		defer C.g_variant_unref(inner)
+		return unmarshalVariantValue(inner, rv)
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if typeString[0] == 'm' {
+			if C.g_variant_n_children(v) == 0 {
+				rv.Set(reflect.Zero(rv.Type()))
+				return nil
+			}
+			child := C.g_variant_get_child_value(v, 0)
+			defer C.g_variant_unref(child)
+			v = child
+		}
+		elem := reflect.New(rv.Type().Elem())
+		if err := unmarshalVariantValue(v, elem.Elem()); err != nil {
+			return err
+		}
+		rv.Set(elem)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if typeString != "b" {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		rv.SetBool(gobool(C.g_variant_get_boolean(v)))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return unmarshalVariantInteger(v, typeString, rv)
+
+	case reflect.Float32, reflect.Float64:
+		if typeString != "d" {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		rv.SetFloat(float64(C.g_variant_get_double(v)))
+
+	case reflect.String:
+		if typeString != "s" && typeString != "o" && typeString != "g" {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		var n C.gsize
+		s := C.g_variant_get_string(v, &n)
+		rv.SetString(C.GoStringN((*C.char)(s), C.int(n)))
+
+	case reflect.Slice, reflect.Array:
+		if typeString[0] != 'a' || strings.HasPrefix(typeString, "a{") && rv.Type().Elem().Kind() != reflect.Struct {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		n := int(C.g_variant_n_children(v))
+		if rv.Kind() == reflect.Array && rv.Len() != n {
+			return fmt.Errorf("cannot unmarshal %d elements into %s: %w", n, rv.Type(), ErrVariantTypeMismatch)
+		}
+
+		if typeString == "ay" && rv.Type().Elem().Kind() == reflect.Uint8 {
+			var size C.gsize
+			data := C.g_variant_get_fixed_array(v, &size, 1)
+			bytes := C.GoBytes(unsafe.Pointer(data), C.int(size))
+			if rv.Kind() == reflect.Slice {
+				rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+			}
+			reflect.Copy(rv, reflect.ValueOf(bytes))
+			return nil
+		}
+
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+		}
+		for i := 0; i < n; i++ {
+			child := C.g_variant_get_child_value(v, C.gsize(i))
+			err := unmarshalVariantValue(child, rv.Index(i))
+			C.g_variant_unref(child)
+			if err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+
+	case reflect.Map:
+		if !strings.HasPrefix(typeString, "a{") {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		n := int(C.g_variant_n_children(v))
+		m := reflect.MakeMapWithSize(rv.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(rv.Type().Key()).Elem()
+			value := reflect.New(rv.Type().Elem()).Elem()
+
+			entry := C.g_variant_get_child_value(v, C.gsize(i))
+			ckey := C.g_variant_get_child_value(entry, 0)
+			cvalue := C.g_variant_get_child_value(entry, 1)
+			err := unmarshalVariantValue(ckey, key)
+			if err == nil {
+				err = unmarshalVariantValue(cvalue, value)
+			}
+			C.g_variant_unref(cvalue)
+			C.g_variant_unref(ckey)
+			C.g_variant_unref(entry)
+			if err != nil {
+				return fmt.Errorf("entry %d: %w", i, err)
+			}
+			m.SetMapIndex(key, value)
+		}
+		rv.Set(m)
+
+	case reflect.Struct:
+		if typeString[0] != '(' && typeString[0] != '{' {
+			return variantUnmarshalMismatch(v, rv)
+		}
+		fields, err := variantFields(rv.Type(), nil)
+		if err != nil {
+			return err
+		}
+		n := int(C.g_variant_n_children(v))
+		if n != len(fields) {
+			return fmt.Errorf("cannot unmarshal %d tuple elements into the %d fields of %s: %w", n, len(fields), rv.Type(), ErrVariantTypeMismatch)
+		}
+		for i, f := range fields {
+			child := C.g_variant_get_child_value(v, C.gsize(i))
+			err := unmarshalVariantValue(child, rv.Field(f.index))
+			C.g_variant_unref(child)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", rv.Type().Field(f.index).Name, err)
+			}
+		}
+
+	default:
+		return variantUnmarshalMismatch(v, rv)
+	}
+	return nil
+}
+
+// unmarshalVariantInteger stores an integer variant in an integer rv, failing if it does not fit.
+func unmarshalVariantInteger(v *C.GVariant, typeString string, rv reflect.Value) error {
+	var i int64
+	var u uint64
+	signed := true
+
+	switch typeString {
+	case "n":
+		i = int64(C.g_variant_get_int16(v))
+	case "i":
+		i = int64(C.g_variant_get_int32(v))
+	case "h":
+		i = int64(C.g_variant_get_handle(v))
+	case "x":
+		i = int64(C.g_variant_get_int64(v))
+	case "y":
+		u, signed = uint64(C.g_variant_get_byte(v)), false
+	case "q":
+		u, signed = uint64(C.g_variant_get_uint16(v)), false
+	case "u":
+		u, signed = uint64(C.g_variant_get_uint32(v)), false
+	case "t":
+		u, signed = uint64(C.g_variant_get_uint64(v)), false
+	default:
+		return variantUnmarshalMismatch(v, rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !signed {
+			if u > math.MaxInt64 {
+				return fmt.Errorf("%d overflows %s: %w", u, rv.Type(), ErrVariantTypeMismatch)
+			}
+			i = int64(u)
+		}
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("%d overflows %s: %w", i, rv.Type(), ErrVariantTypeMismatch)
+		}
+		rv.SetInt(i)
+	default:
+		if signed {
+			if i < 0 {
+				return fmt.Errorf("%d overflows %s: %w", i, rv.Type(), ErrVariantTypeMismatch)
+			}
+			u = uint64(i)
+		}
+		if rv.OverflowUint(u) {
+			return fmt.Errorf("%d overflows %s: %w", u, rv.Type(), ErrVariantTypeMismatch)
+		}
+		rv.SetUint(u)
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package glib_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type variantTestPoint struct {
+	X, Y int32
+}
+
+type variantTestRecord struct {
+	Name     string
+	Path     string `variant:"o"`
+	Count    uint8
+	Points   []variantTestPoint
+	Labels   map[string]int64
+	Parent   *variantTestPoint
+	Extra    interface{}
+	Data     []byte
+	Ignored  string `variant:"-"`
+	internal int
+}
+
+func TestMarshalVariant(t *testing.T) {
+	in := variantTestRecord{
+		Name:   "rec",
+		Path:   "/org/example/rec",
+		Count:  3,
+		Points: []variantTestPoint{{1, 2}, {3, 4}},
+		Labels: map[string]int64{"b": 2, "a": 1},
+		Extra:  "boxed",
+		Data:   []byte{0, 1, 2},
+	}
+
+	typeString, err := glib.VariantTypeStringOf(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typeString != "(soya(ii)a{sx}m(ii)vay)" {
+		t.Fatalf("unexpected type string %s", typeString)
+	}
+
+	v, err := glib.MarshalVariant(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.TypeString() != typeString {
+		t.Fatalf("expected %s, got %s", typeString, v.TypeString())
+	}
+	expected := `('rec', '/org/example/rec', 0x03, [(1, 2), (3, 4)], {'a': 1, 'b': 2}, nothing, <'boxed'>, [0x00, 0x01, 0x02])`
+	if v.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, v.String())
+	}
+
+	var out variantTestRecord
+	if err := glib.UnmarshalVariant(v, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+
+	in.Parent = &variantTestPoint{5, 6}
+	if v, err = glib.MarshalVariant(in); err != nil {
+		t.Fatal(err)
+	}
+	out = variantTestRecord{}
+	if err := glib.UnmarshalVariant(v, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Parent == nil || *out.Parent != *in.Parent {
+		t.Fatalf("expected parent %v, got %v", in.Parent, out.Parent)
+	}
+
+	var small int8
+	if err := glib.UnmarshalVariant(glib.VariantFromInt32(1000), &small); !errors.Is(err, glib.ErrVariantTypeMismatch) {
+		t.Fatalf("expected an overflow, got %v", err)
+	}
+	var s string
+	if err := glib.UnmarshalVariant(glib.VariantFromInt32(1), &s); !errors.Is(err, glib.ErrVariantTypeMismatch) {
+		t.Fatalf("expected a type mismatch, got %v", err)
+	}
+	if _, err := glib.MarshalVariant(variantTestRecord{Path: "not a path"}); err == nil {
+		t.Fatal("expected an invalid object path to fail")
+	}
+	if _, err := glib.MarshalVariant(make(chan int)); !errors.Is(err, glib.ErrVariantTypeMismatch) {
+		t.Fatalf("expected a type mismatch, got %v", err)
+	}
+}
+
+func TestVariantContainers(t *testing.T) {
+	b := glib.VariantBuilderNew(glib.VariantTypeNew("(sai)"))
+	b.AddValue(glib.VariantFromString("numbers"))
+	b.Open(glib.VariantTypeNew("ai"))
+	for _, i := range []int32{1, 2, 3} {
+		if err := b.Add(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.Close()
+	tuple := b.End()
+	if tuple.String() != "('numbers', [1, 2, 3])" {
+		t.Fatalf("unexpected tuple %s", tuple)
+	}
+
+	numbers := tuple.ChildValue(1)
+	var sum int64
+	for i, child := range numbers.Children() {
+		n, err := child.GetInt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(i+1) {
+			t.Fatalf("expected %d at index %d, got %d", i+1, i, n)
+		}
+		sum += n
+	}
+	if sum != 6 {
+		t.Fatalf("expected the sum 6, got %d", sum)
+	}
+
+	it := numbers.Iter()
+	if it.NChildren() != 3 {
+		t.Fatalf("expected 3 children, got %d", it.NChildren())
+	}
+	it.Next()
+	var rest []*glib.Variant
+	for child := range it.Values() {
+		rest = append(rest, child)
+	}
+	if len(rest) != 2 || rest[0].String() != "2" {
+		t.Fatalf("unexpected remaining children %v", rest)
+	}
+
+	d := glib.VariantDictNew(nil)
+	if err := d.Insert("name", "gopher"); err != nil {
+		t.Fatal(err)
+	}
+	d.InsertValue("age", glib.VariantFromUint32(14))
+	d.InsertValue("drop", glib.VariantFromBoolean(true))
+	if !d.Contains("drop") || !d.Remove("drop") || d.Contains("drop") {
+		t.Fatal("expected drop to be removed")
+	}
+	if v := d.LookupValue("name", glib.VARIANT_TYPE_STRING); v == nil || v.GetString() != "gopher" {
+		t.Fatalf("unexpected name %v", v)
+	}
+	if v := d.LookupValue("name", glib.VARIANT_TYPE_BOOLEAN); v != nil {
+		t.Fatalf("expected no boolean name, got %v", v)
+	}
+
+	dict := d.End()
+	if dict.TypeString() != "a{sv}" {
+		t.Fatalf("expected a vardict, got %s", dict.TypeString())
+	}
+	entries := map[string]string{}
+	for key, value := range dict.Entries() {
+		entries[key.GetString()] = value.GetVariant().String()
+	}
+	if !reflect.DeepEqual(entries, map[string]string{"name": "'gopher'", "age": "14"}) {
+		t.Fatalf("unexpected entries %v", entries)
+	}
+	if v := dict.LookupValue("age", nil); v == nil || v.String() != "14" {
+		t.Fatalf("unexpected age %v", v)
+	}
+}
+
+func TestVariantParse(t *testing.T) {
+	v, err := glib.VariantParse(nil, "('x', [1, 2], {'a': <true>})")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.TypeString() != "(saia{sv})" {
+		t.Fatalf("unexpected type %s", v.TypeString())
+	}
+
+	if v, err := glib.VariantParse(glib.VARIANT_TYPE_BYTE, "7"); err != nil || v.TypeString() != "y" {
+		t.Fatalf("expected a byte, got %v (%v)", v, err)
+	}
+
+	_, err = glib.VariantParse(nil, "[1, 'x']")
+	var parseErr *glib.VariantParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if parseErr.Start != 1 || parseErr.End != 7 || parseErr.Context == "" {
+		t.Fatalf("unexpected error location %d-%d in %q", parseErr.Start, parseErr.End, parseErr.Context)
+	}
+
+	data := v.Data()
+	if uint(len(data)) != v.Size() {
+		t.Fatalf("expected %d bytes, got %d", v.Size(), len(data))
+	}
+	loaded := glib.VariantFromData(v.Type(), data, false)
+	if !loaded.Equal(v) || !loaded.IsNormalForm() {
+		t.Fatalf("expected %s, got %s", v, loaded)
+	}
+	if swapped := v.Byteswap(); !swapped.Byteswap().Equal(v) {
+		t.Fatalf("expected swapping twice to restore %s, got %s", v, swapped.Byteswap())
+	}
+
+	// an array of strings missing its terminating NUL byte and offset
+	broken := glib.VariantFromData(glib.VARIANT_TYPE_STRING_ARRAY, []byte("abc"), false)
+	if broken.IsNormalForm() {
+		t.Fatal("expected broken data not to be in normal form")
+	}
+	if normal := broken.NormalForm(); !normal.IsNormalForm() {
+		t.Fatalf("expected %s to be in normal form", normal)
+	}
+}
+
+func FuzzVariantParse(f *testing.F) {
+	for _, seed := range []string{
+		"1", "'x'", "[1, 2]", "@as []", "{'a': <1>}", "(1, 'x', [true])", "just 3", "nothing",
+		"[1, 'x']", "<", "{", "@a{sv} {}", "0x10", "b'bytes'", "objectpath '/a'",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		v, err := glib.VariantParse(nil, text)
+		if err != nil {
+			var parseErr *glib.VariantParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected a parse error, got %T", err)
+			}
+			if parseErr.Start < 0 || parseErr.End < parseErr.Start || parseErr.End > len(text)+1 {
+				t.Fatalf("error location %d-%d outside of %q", parseErr.Start, parseErr.End, text)
+			}
+			return
+		}
+
+		reparsed, err := glib.VariantParse(v.Type(), v.AnnotatedString())
+		if err != nil {
+			t.Fatalf("could not parse the printed form %q of %q: %v", v.AnnotatedString(), text, err)
+		}
+		if !reparsed.Equal(v) {
+			t.Fatalf("expected %s, got %s", v, reparsed)
+		}
+
+		loaded := glib.VariantFromData(v.Type(), v.Data(), false)
+		if !loaded.Equal(v) {
+			t.Fatalf("expected %s from the serialized data, got %s", v, loaded)
+		}
+	})
+}
+
+func TestVariantJSON(t *testing.T) {
+	v, err := glib.VariantFromJSON([]byte(`{"name": "x", "sizes": [1, 2.5], "big": 18446744073709551615, "none": null}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{'big': <uint64 18446744073709551615>, 'name': <'x'>, 'none': <@mv nothing>, 'sizes': <[<int64 1>, <2.5>]>}"
+	if v.AnnotatedString() != expected {
+		t.Fatalf("expected %s, got %s", expected, v.AnnotatedString())
+	}
+
+	typ := glib.VariantTypeNew("(sa{ix}mayo)")
+	v, err = glib.VariantFromJSON([]byte(`["x", {"1": 9007199254740993, "-2": 3}, "AAEC", "/a/b"]`), typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "('x', {-2: 3, 1: 9007199254740993}, [0x00, 0x01, 0x02], '/a/b')" {
+		t.Fatalf("unexpected variant %s", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["x",{"-2":3,"1":"9007199254740993"},"AAEC","/a/b"]` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+	back, err := glib.VariantFromJSON(data, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.Equal(v) {
+		t.Fatalf("expected %s, got %s", v, back)
+	}
+
+	for _, tt := range []struct{ json, typ string }{
+		{`300`, "y"},
+		{`"x"`, "i"},
+		{`[1]`, "(ii)"},
+		{`"not a path"`, "o"},
+		{`1 2`, "i"},
+	} {
+		if _, err := glib.VariantFromJSON([]byte(tt.json), glib.VariantTypeNew(tt.typ)); err == nil {
+			t.Errorf("expected converting %s to %s to fail", tt.json, tt.typ)
+		}
+	}
+}
+
+func TestVariantTypes(t *testing.T) {
+	entry := glib.VariantTypeNewDictEntry(glib.VARIANT_TYPE_STRING, glib.VARIANT_TYPE_VARIANT)
+	dict := glib.VariantTypeNewArray(entry)
+	tuple := glib.VariantTypeNewTuple(glib.VariantTypeNewMaybe(glib.VARIANT_TYPE_INT32), dict, glib.VariantTypeNewTuple())
+	if tuple.String() != "(mia{sv}())" {
+		t.Fatalf("unexpected type %s", tuple)
+	}
+	if !tuple.IsTuple() || !tuple.IsDefinite() || !tuple.IsContainer() || tuple.IsBasic() || tuple.NItems() != 3 {
+		t.Fatalf("unexpected inspection of %s", tuple)
+	}
+
+	var items []string
+	for item := range tuple.Items() {
+		items = append(items, item.String())
+	}
+	if !reflect.DeepEqual(items, []string{"mi", "a{sv}", "()"}) {
+		t.Fatalf("unexpected items %v", items)
+	}
+
+	second := tuple.First().Next()
+	if !second.IsArray() || !glib.VariantTypeEqual(second, glib.VARIANT_TYPE_VARDICT) {
+		t.Fatalf("expected a{sv}, got %s", second)
+	}
+	elem := second.Element()
+	if !elem.IsDictEntry() || elem.Key().String() != "s" || !elem.Value().IsVariant() {
+		t.Fatalf("unexpected dict entry %s", elem)
+	}
+	if elem.Key().Next().String() != "v" || elem.Value().Next() != nil || second.Next().Next() != nil {
+		t.Fatal("unexpected next items")
+	}
+	if elem.Next() != nil {
+		t.Fatal("expected the element of an array to have no next item")
+	}
+	if glib.VariantTypeNewTuple().First() != nil {
+		t.Fatal("expected the unit type to have no items")
+	}
+
+	if glib.VARIANT_TYPE_ANY.IsDefinite() || !glib.VARIANT_TYPE_STRING.IsBasic() || !tuple.First().IsMaybe() {
+		t.Fatal("unexpected inspection of basic types")
+	}
+	if c := tuple.Copy(); !glib.VariantTypeEqual(c, tuple) {
+		t.Fatalf("expected a copy of %s, got %s", tuple, c)
+	}
+}
@@ -10,7 +10,11 @@ package glib
 // #include "glib.go.h"
 // #include "gvariant.go.h"
 import "C"
-import "unsafe"
+
+import (
+	"runtime"
+	"unsafe"
+)
 
 /*
  * GVariantBuilder
@@ -50,3 +54,48 @@ func (v *VariantBuilder) native() *C.GVariantBuilder {
 func (v *VariantBuilder) Native() unsafe.Pointer {
 	return unsafe.Pointer(v.native())
 }
+
+// VariantBuilderNew is a wrapper around g_variant_builder_new(). It creates a builder for a container
+// variant of type t, usually an array, maybe, tuple or dictionary entry type.
+func VariantBuilderNew(t *VariantType) *VariantBuilder {
+	b := newVariantBuilder(C.g_variant_builder_new(t.native()))
+	runtime.SetFinalizer(b, (*VariantBuilder).unref)
+	return b
+}
+
+func (v *VariantBuilder) unref() {
+	C.g_variant_builder_unref(v.native())
+}
+
+// AddValue is a wrapper around g_variant_builder_add_value(). It adds value as the next child of the
+// container that is currently built.
+func (v *VariantBuilder) AddValue(value *Variant) {
+	C.g_variant_builder_add_value(v.native(), value.native())
+}
+
+// Add marshals value with MarshalVariant and adds it as the next child.
+func (v *VariantBuilder) Add(value interface{}) error {
+	variant, err := MarshalVariant(value)
+	if err != nil {
+		return err
+	}
+	v.AddValue(variant)
+	return nil
+}
+
+// Open is a wrapper around g_variant_builder_open(). It opens a nested container of type t, the
+// children added until the matching Close are added to it.
+func (v *VariantBuilder) Open(t *VariantType) {
+	C.g_variant_builder_open(v.native(), t.native())
+}
+
+// Close is a wrapper around g_variant_builder_close(). It closes the container opened last.
+func (v *VariantBuilder) Close() {
+	C.g_variant_builder_close(v.native())
+}
+
+// End is a wrapper around g_variant_builder_end(). It returns the built variant and resets the
+// builder, so it can not be used anymore.
+func (v *VariantBuilder) End() *Variant {
+	return takeVariant(C.g_variant_builder_end(v.native()))
+}
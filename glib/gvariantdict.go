@@ -10,7 +10,11 @@ package glib
 // #include "glib.go.h"
 // #include "gvariant.go.h"
 import "C"
-import "unsafe"
+
+import (
+	"runtime"
+	"unsafe"
+)
 
 /*
  * GVariantDict
@@ -50,3 +54,59 @@ func (v *VariantDict) native() *C.GVariantDict {
 func (v *VariantDict) Native() unsafe.Pointer {
 	return unsafe.Pointer(v.native())
 }
+
+// VariantDictNew is a wrapper around g_variant_dict_new(). It creates a mutable dictionary with the
+// entries of the a{sv} variant from, which may be nil.
+func VariantDictNew(from *Variant) *VariantDict {
+	d := newVariantDict(C.g_variant_dict_new(from.native()))
+	runtime.SetFinalizer(d, (*VariantDict).unref)
+	return d
+}
+
+func (v *VariantDict) unref() {
+	C.g_variant_dict_unref(v.native())
+}
+
+// Contains is a wrapper around g_variant_dict_contains().
+func (v *VariantDict) Contains(key string) bool {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	return gobool(C.g_variant_dict_contains(v.native(), (*C.gchar)(ckey)))
+}
+
+// LookupValue is a wrapper around g_variant_dict_lookup_value(). It returns nil if key is not in the
+// dictionary or its value is not of expectedType. expectedType may be nil to accept any type.
+func (v *VariantDict) LookupValue(key string, expectedType *VariantType) *Variant {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	return wrapFullVariant(C.g_variant_dict_lookup_value(v.native(), (*C.gchar)(ckey), expectedType.native()))
+}
+
+// InsertValue is a wrapper around g_variant_dict_insert_value(). It replaces any existing value of key.
+func (v *VariantDict) InsertValue(key string, value *Variant) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.g_variant_dict_insert_value(v.native(), (*C.gchar)(ckey), value.native())
+}
+
+// Insert marshals value with MarshalVariant and inserts it for key.
+func (v *VariantDict) Insert(key string, value interface{}) error {
+	variant, err := MarshalVariant(value)
+	if err != nil {
+		return err
+	}
+	v.InsertValue(key, variant)
+	return nil
+}
+
+// Remove is a wrapper around g_variant_dict_remove(). It returns true if key was in the dictionary.
+func (v *VariantDict) Remove(key string) bool {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	return gobool(C.g_variant_dict_remove(v.native(), (*C.gchar)(ckey)))
+}
+
+// End is a wrapper around g_variant_dict_end(). It returns the a{sv} variant and empties the dictionary.
+func (v *VariantDict) End() *Variant {
+	return takeVariant(C.g_variant_dict_end(v.native()))
+}
@@ -10,7 +10,12 @@ package glib
 // #include "glib.go.h"
 // #include "gvariant.go.h"
 import "C"
-import "unsafe"
+
+import (
+	"iter"
+	"runtime"
+	"unsafe"
+)
 
 /*
  * GVariantIter
@@ -50,3 +55,84 @@ func (v *VariantIter) native() *C.GVariantIter {
 func (v *VariantIter) Native() unsafe.Pointer {
 	return unsafe.Pointer(v.native())
 }
+
+// Iter is a wrapper around g_variant_iter_new(). It returns an iterator over the children of the
+// container variant v.
+func (v *Variant) Iter() *VariantIter {
+	it := newVariantIter(C.g_variant_iter_new(v.native()))
+	runtime.SetFinalizer(it, (*VariantIter).free)
+	return it
+}
+
+func (v *VariantIter) free() {
+	C.g_variant_iter_free(v.native())
+}
+
+// NChildren is a wrapper around g_variant_iter_n_children(). It returns the number of children of
+// the container, independent of the progress of the iterator.
+func (v *VariantIter) NChildren() uint {
+	return uint(C.g_variant_iter_n_children(v.native()))
+}
+
+// Next is a wrapper around g_variant_iter_next_value(). It returns the next child, or nil if there
+// are no more children.
+func (v *VariantIter) Next() *Variant {
+	return wrapFullVariant(C.g_variant_iter_next_value(v.native()))
+}
+
+// Values returns an iterator over the remaining children.
+func (v *VariantIter) Values() iter.Seq[*Variant] {
+	return func(yield func(*Variant) bool) {
+		for child := v.Next(); child != nil; child = v.Next() {
+			if !yield(child) {
+				return
+			}
+		}
+	}
+}
+
+// NChildren is a wrapper around g_variant_n_children(). It returns the number of children of a
+// container variant.
+func (v *Variant) NChildren() uint {
+	return uint(C.g_variant_n_children(v.native()))
+}
+
+// ChildValue is a wrapper around g_variant_get_child_value(). It returns the child at index i of a
+// container variant, i must be less than NChildren.
+func (v *Variant) ChildValue(i uint) *Variant {
+	return wrapFullVariant(C.g_variant_get_child_value(v.native(), C.gsize(i)))
+}
+
+// Children returns an iterator over the indices and children of a container variant, e.g. the
+// elements of an array or tuple.
+func (v *Variant) Children() iter.Seq2[int, *Variant] {
+	return func(yield func(int, *Variant) bool) {
+		n := v.NChildren()
+		for i := uint(0); i < n; i++ {
+			if !yield(int(i), v.ChildValue(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Entries returns an iterator over the keys and values of a dictionary variant (a{..}). Values of
+// a{sv} dictionaries are returned boxed, use GetVariant to unbox them.
+func (v *Variant) Entries() iter.Seq2[*Variant, *Variant] {
+	return func(yield func(*Variant, *Variant) bool) {
+		for _, entry := range v.Children() {
+			if !yield(entry.ChildValue(0), entry.ChildValue(1)) {
+				return
+			}
+		}
+	}
+}
+
+// LookupValue is a wrapper around g_variant_lookup_value(). It looks up key in a dictionary variant
+// of type a{s*} or a{o*}, returning nil if key is missing or its value is not of expectedType.
+// expectedType may be nil to accept any type.
+func (v *Variant) LookupValue(key string, expectedType *VariantType) *Variant {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	return wrapFullVariant(C.g_variant_lookup_value(v.native(), (*C.gchar)(ckey), expectedType.native()))
+}
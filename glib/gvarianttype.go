@@ -11,6 +11,7 @@ package glib
 import "C"
 
 import (
+	"iter"
 	"runtime"
 	"unsafe"
 )
@@ -19,6 +20,9 @@ import (
 // information for GVariants.
 type VariantType struct {
 	GVariantType *C.GVariantType
+
+	// owner is the type this type is part of, see borrowVariantType.
+	owner *VariantType
 }
 
 func (v *VariantType) native() *C.GVariantType {
@@ -42,7 +46,16 @@ func newVariantType(v *C.GVariantType) *VariantType {
 	if v == nil {
 		return nil
 	}
-	return &VariantType{v}
+	return &VariantType{GVariantType: v}
+}
+
+// borrowVariantType wraps a native GVariantType that is part of owner,
+// keeping owner alive while the returned type is in use.
+func (owner *VariantType) borrowVariantType(v *C.GVariantType) *VariantType {
+	if v == nil {
+		return nil
+	}
+	return &VariantType{GVariantType: v, owner: owner}
 }
 
 // takeVariantType wraps a native GVariantType
@@ -51,7 +64,7 @@ func takeVariantType(v *C.GVariantType) *VariantType {
 	if v == nil {
 		return nil
 	}
-	obj := &VariantType{v}
+	obj := &VariantType{GVariantType: v}
 	runtime.SetFinalizer(obj, (*VariantType).Free)
 	return obj
 }
@@ -123,25 +136,132 @@ func (v *VariantType) IsSubtypeOf(supertype *VariantType) bool {
 	return gobool(C.g_variant_type_is_subtype_of(v.native(), supertype.native()))
 }
 
+// VariantTypeNewArray is a wrapper around g_variant_type_new_array. It creates
+// the type of arrays of element.
+func VariantTypeNewArray(element *VariantType) *VariantType {
+	return takeVariantType(C.g_variant_type_new_array(element.native()))
+}
+
+// VariantTypeNewMaybe is a wrapper around g_variant_type_new_maybe. It creates
+// the maybe type of element.
+func VariantTypeNewMaybe(element *VariantType) *VariantType {
+	return takeVariantType(C.g_variant_type_new_maybe(element.native()))
+}
+
+// VariantTypeNewTuple is a wrapper around g_variant_type_new_tuple. It creates
+// the type of tuples of items, no items create the unit type.
+func VariantTypeNewTuple(items ...*VariantType) *VariantType {
+	citems := make([]*C.GVariantType, len(items))
+	for i, item := range items {
+		citems[i] = item.native()
+	}
+	return takeVariantType(C.g_variant_type_new_tuple(unsafe.SliceData(citems), C.gint(len(citems))))
+}
+
+// VariantTypeNewDictEntry is a wrapper around g_variant_type_new_dict_entry. key
+// must be a basic type.
+func VariantTypeNewDictEntry(key, value *VariantType) *VariantType {
+	return takeVariantType(C.g_variant_type_new_dict_entry(key.native(), value.native()))
+}
+
+// Copy is a wrapper around g_variant_type_copy.
+func (v *VariantType) Copy() *VariantType {
+	return takeVariantType(C.g_variant_type_copy(v.native()))
+}
+
+// IsDefinite is a wrapper around g_variant_type_is_definite. It returns false
+// for types that contain indefinite types like VARIANT_TYPE_ANY.
+func (v *VariantType) IsDefinite() bool {
+	return gobool(C.g_variant_type_is_definite(v.native()))
+}
+
+// IsContainer is a wrapper around g_variant_type_is_container.
+func (v *VariantType) IsContainer() bool {
+	return gobool(C.g_variant_type_is_container(v.native()))
+}
+
+// IsBasic is a wrapper around g_variant_type_is_basic.
+func (v *VariantType) IsBasic() bool {
+	return gobool(C.g_variant_type_is_basic(v.native()))
+}
+
+// IsMaybe is a wrapper around g_variant_type_is_maybe.
+func (v *VariantType) IsMaybe() bool {
+	return gobool(C.g_variant_type_is_maybe(v.native()))
+}
+
+// IsArray is a wrapper around g_variant_type_is_array.
+func (v *VariantType) IsArray() bool {
+	return gobool(C.g_variant_type_is_array(v.native()))
+}
+
+// IsTuple is a wrapper around g_variant_type_is_tuple.
+func (v *VariantType) IsTuple() bool {
+	return gobool(C.g_variant_type_is_tuple(v.native()))
+}
+
+// IsDictEntry is a wrapper around g_variant_type_is_dict_entry.
+func (v *VariantType) IsDictEntry() bool {
+	return gobool(C.g_variant_type_is_dict_entry(v.native()))
+}
+
+// IsVariant is a wrapper around g_variant_type_is_variant.
+func (v *VariantType) IsVariant() bool {
+	return gobool(C.g_variant_type_is_variant(v.native()))
+}
+
+// Element is a wrapper around g_variant_type_element. It returns the element
+// type of an array or maybe type.
+func (v *VariantType) Element() *VariantType {
+	return v.borrowVariantType(C.g_variant_type_element(v.native()))
+}
+
+// Key is a wrapper around g_variant_type_key. It returns the key type of a
+// dict entry type.
+func (v *VariantType) Key() *VariantType {
+	return v.borrowVariantType(C.g_variant_type_key(v.native()))
+}
+
+// Value is a wrapper around g_variant_type_value. It returns the value type of
+// a dict entry type.
+func (v *VariantType) Value() *VariantType {
+	return v.borrowVariantType(C.g_variant_type_value(v.native()))
+}
+
+// NItems is a wrapper around g_variant_type_n_items. It returns the number of
+// items of a tuple or dict entry type.
+func (v *VariantType) NItems() uint {
+	return uint(C.g_variant_type_n_items(v.native()))
+}
+
+// First is a wrapper around g_variant_type_first. It returns the first item of
+// a tuple or dict entry type, or nil for the unit type.
+func (v *VariantType) First() *VariantType {
+	return v.borrowVariantType(C.g_variant_type_first(v.native()))
+}
+
+// Next is a wrapper around g_variant_type_next. It returns the item following v
+// in the tuple or dict entry type v was returned from by First, Next or Key, or
+// nil if v is the last item.
+func (v *VariantType) Next() *VariantType {
+	// only items of tuples and dict entries are followed by other items
+	if v.owner == nil || !v.owner.IsTuple() && !v.owner.IsDictEntry() {
+		return nil
+	}
+	return v.owner.borrowVariantType(C.g_variant_type_next(v.native()))
+}
+
+// Items returns an iterator over the items of a tuple or dict entry type.
+func (v *VariantType) Items() iter.Seq[*VariantType] {
+	return func(yield func(*VariantType) bool) {
+		for item := v.First(); item != nil; item = item.Next() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 // TODO:
-// g_variant_type_copy
 // g_variant_type_string_scan
-// g_variant_type_is_definite
-// g_variant_type_is_container
-// g_variant_type_is_basic
-// g_variant_type_is_maybe
-// g_variant_type_is_array
-// g_variant_type_is_tuple
-// g_variant_type_is_dict_entry
-// g_variant_type_is_variant
 // g_variant_type_hash
-// g_variant_type_new_maybe
-// g_variant_type_new_array
-// g_variant_type_new_tuple
-// g_variant_type_new_dict_entry
-// g_variant_type_element
-// g_variant_type_n_items
-// g_variant_type_first
-// g_variant_type_next
-// g_variant_type_key
-// g_variant_type_value
@@ -0,0 +1,87 @@
+package glib
+
+/*
+#include "glib.go.h"
+
+extern void goWeakNotify (gpointer data, GObject * where_the_object_was);
+
+static void cgoWeakNotify (gpointer data, GObject * where_the_object_was)
+{
+	goWeakNotify(data, where_the_object_was);
+}
+
+static void _g_object_weak_ref   (GObject * object, gpointer data) { g_object_weak_ref(object, cgoWeakNotify, data); }
+static void _g_object_weak_unref (GObject * object, gpointer data) { g_object_weak_unref(object, cgoWeakNotify, data); }
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+// WeakRef is a binding around GWeakRef. It holds a weak reference to an Object that is safe to use
+// from any thread. Get returns nil once the object has been finalized.
+type WeakRef struct {
+	ref *C.GWeakRef
+}
+
+// NewWeakRef creates a new WeakRef pointing to obj. obj may be nil.
+func NewWeakRef(obj IObject) *WeakRef {
+	// the GWeakRef must not be moved, so it is allocated in C memory
+	w := &WeakRef{ref: (*C.GWeakRef)(C.calloc(1, C.sizeof_GWeakRef))}
+	C.g_weak_ref_init(w.ref, C.gpointer(objectOrNil(obj)))
+
+	runtime.SetFinalizer(w, (*WeakRef).free)
+	return w
+}
+
+func objectOrNil(obj IObject) *C.GObject {
+	if obj == nil {
+		return nil
+	}
+	return obj.toGObject()
+}
+
+func (w *WeakRef) free() {
+	C.g_weak_ref_clear(w.ref)
+	C.free(unsafe.Pointer(w.ref))
+}
+
+// Get returns a new strong reference to the object, or nil if the object has been finalized.
+func (w *WeakRef) Get() *Object {
+	obj := C.g_weak_ref_get(w.ref)
+	runtime.KeepAlive(w)
+	if obj == nil {
+		return nil
+	}
+	return TransferFull(unsafe.Pointer(obj))
+}
+
+// Set changes the object the WeakRef points to. obj may be nil.
+func (w *WeakRef) Set(obj IObject) {
+	C.g_weak_ref_set(w.ref, C.gpointer(objectOrNil(obj)))
+	runtime.KeepAlive(w)
+}
+
+// WeakNotifyHandle identifies a callback added with Object.WeakRef.
+type WeakNotifyHandle struct {
+	ptr unsafe.Pointer
+}
+
+// WeakRef is a wrapper around g_object_weak_ref(). notify is called when the object is finalized,
+// the object must not be accessed from it anymore. The returned handle can be passed to
+// WeakUnref to remove the callback again.
+func (v *Object) WeakRef(notify func()) WeakNotifyHandle {
+	ptr := gopointer.Save(notify)
+	C._g_object_weak_ref(v.native(), C.gpointer(ptr))
+	return WeakNotifyHandle{ptr: ptr}
+}
+
+// WeakUnref is a wrapper around g_object_weak_unref(). It removes a callback added with WeakRef.
+func (v *Object) WeakUnref(handle WeakNotifyHandle) {
+	C._g_object_weak_unref(v.native(), C.gpointer(handle.ptr))
+	gopointer.Unref(handle.ptr)
+}
@@ -0,0 +1,21 @@
+package glib
+
+/*
+#include "glib.go.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/go-gst/go-pointer"
+)
+
+//export goWeakNotify
+func goWeakNotify(data C.gpointer, _ *C.GObject) {
+	ptr := unsafe.Pointer(data)
+	notify := gopointer.Restore(ptr).(func())
+	gopointer.Unref(ptr)
+
+	notify()
+}
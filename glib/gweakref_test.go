@@ -0,0 +1,66 @@
+package glib_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/go-gst/go-glib/glib"
+)
+
+type disposeTestObject struct {
+	disposed  *int
+	finalized *int
+}
+
+var disposeTestCounts struct{ disposed, finalized int }
+
+func (o *disposeTestObject) New() glib.GoObjectSubclass {
+	return &disposeTestObject{disposed: &disposeTestCounts.disposed, finalized: &disposeTestCounts.finalized}
+}
+
+func (o *disposeTestObject) ClassInit(*glib.ObjectClass) {}
+
+func (o *disposeTestObject) Dispose(*glib.Object) { *o.disposed++ }
+
+func (o *disposeTestObject) Finalize(*glib.Object) { *o.finalized++ }
+
+func TestWeakRef(t *testing.T) {
+	disposeTestCounts.disposed, disposeTestCounts.finalized = 0, 0
+
+	typ := glib.RegisterGoType("GoGlibDisposeTestObject", &disposeTestObject{}, glib.ExtendsObject)
+
+	obj, err := glib.NewObjectWithProperties(typ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weak := glib.NewWeakRef(obj)
+	strong := weak.Get()
+	if strong == nil || strong.Native() != obj.Native() {
+		t.Fatal("expected the weak ref to return the object")
+	}
+	releaseObject(strong)
+
+	var notified bool
+	obj.WeakRef(func() { notified = true })
+	removed := obj.WeakRef(func() { t.Error("removed weak notify was called") })
+	obj.WeakUnref(removed)
+
+	releaseObject(obj)
+
+	if weak.Get() != nil {
+		t.Fatal("expected the weak ref to be empty after the object was finalized")
+	}
+	if !notified {
+		t.Fatal("expected the weak notify to be called")
+	}
+	if disposeTestCounts.disposed != 1 || disposeTestCounts.finalized != 1 {
+		t.Fatalf("expected dispose and finalize to be called once, got %d and %d", disposeTestCounts.disposed, disposeTestCounts.finalized)
+	}
+}
+
+// releaseObject drops the reference held by obj deterministically instead of waiting for the go finalizer.
+func releaseObject(obj *glib.Object) {
+	runtime.SetFinalizer(obj, nil)
+	obj.Unref()
+}